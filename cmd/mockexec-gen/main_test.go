@@ -0,0 +1,109 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Generate_FullSurfaceParsesAsValidGo(t *testing.T) {
+	src, err := generate("myservice", "ShellCommand", "")
+	if err != nil {
+		t.Fatalf("generate() failed: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "generated.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+	compileGenerated(t, src)
+
+	for _, want := range []string{
+		"package myservice",
+		"type ShellCommand interface",
+		"type ExecShellCommand struct",
+		"type MockShellCommand struct",
+		"SetDir(string)",
+		"Output() ([]byte, error)",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source missing %q", want)
+		}
+	}
+}
+
+func Test_Generate_MembersRestrictsSurface(t *testing.T) {
+	src, err := generate("myservice", "ShellCommand", "Dir,Output,Run")
+	if err != nil {
+		t.Fatalf("generate() failed: %v", err)
+	}
+
+	if !strings.Contains(string(src), "SetDir(string)") {
+		t.Error("expected SetDir to be included")
+	}
+	if strings.Contains(string(src), "SetEnv(") {
+		t.Error("expected SetEnv to be excluded when -members omits it")
+	}
+	if strings.Contains(string(src), "CombinedOutput(") {
+		t.Error("expected CombinedOutput to be excluded when -members omits it")
+	}
+}
+
+// compileGenerated writes src as the only file of a standalone module and
+// runs `go build` on it, so a regression like an unconditional import that
+// goes unused for some -members subset fails the test instead of silently
+// shipping (parsing alone, or substring checks, wouldn't catch that).
+func compileGenerated(t *testing.T, src []byte) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module generated\n\ngo 1.18\n"), 0o644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "generated.go"), src, 0o644); err != nil {
+		t.Fatalf("failed to write generated.go: %v", err)
+	}
+
+	cmd := exec.Command("go", "build", "./...")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated source does not compile: %v\n%s\n%s", err, out, src)
+	}
+}
+
+func Test_Generate_MembersRestrictsSurface_CompilesForEachSubset(t *testing.T) {
+	for _, members := range []string{
+		"Dir,Output,Run",
+		"Env,Start,Wait",
+		"Stdin,StdinPipe",
+		"ExtraFiles,StdoutPipe,StderrPipe",
+		"SysProcAttr",
+		"Cancel,WaitDelay,Environ,String",
+	} {
+		t.Run(members, func(t *testing.T) {
+			src, err := generate("myservice", "ShellCommand", members)
+			if err != nil {
+				t.Fatalf("generate() failed: %v", err)
+			}
+			compileGenerated(t, src)
+		})
+	}
+}
+
+func Test_Generate_UnknownMembersYieldsEmptySurface(t *testing.T) {
+	src, err := generate("myservice", "ShellCommand", "NoSuchMember")
+	if err != nil {
+		t.Fatalf("generate() failed: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "generated.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+	if strings.Contains(string(src), "SetDir(") {
+		t.Error("expected no fields/methods to be included")
+	}
+}
@@ -0,0 +1,84 @@
+// Command mockexec-record wraps an arbitrary command invocation, runs it
+// for real, and appends what it did - argv, stdout, stderr, exit code - to
+// a transcript file in mockexec's fixture format, so building a realistic
+// transcript for LoadTranscripts is a one-command affair instead of a
+// hand-written Transcript literal.
+//
+// Usage:
+//
+//	mockexec-record -o transcript.json -- git rev-parse --abbrev-ref HEAD
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"mock_exec/mockexec"
+)
+
+func main() {
+	out := flag.String("o", "transcript.json", "transcript file to append to")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "mockexec-record: usage: mockexec-record -o transcript.json -- <command> [args...]")
+		os.Exit(2)
+	}
+
+	t, runErr := runAndCapture(args[0], args[1:])
+	if err := appendTranscript(*out, t); err != nil {
+		fmt.Fprintln(os.Stderr, "mockexec-record:", err)
+		os.Exit(1)
+	}
+
+	os.Stdout.WriteString(t.Stdout)
+	os.Stderr.WriteString(t.Stderr)
+	if runErr != nil {
+		os.Exit(t.ExitCode)
+	}
+}
+
+// runAndCapture runs name/arg as a real subprocess and returns what it did
+// as a mockexec.Transcript, the same shape TranscriptRecorder.Commander
+// builds for its in-process recording.
+func runAndCapture(name string, arg []string) (mockexec.Transcript, error) {
+	cmd := mockexec.NewExecShellCommander(name, arg...)
+	var stdout, stderr bytes.Buffer
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(&stderr)
+
+	runErr := cmd.Run()
+	return mockexec.Transcript{
+		Name:     name,
+		Args:     arg,
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		ExitCode: cmd.ExitCode(),
+	}, runErr
+}
+
+// appendTranscript reads any transcripts already saved at path (treating a
+// missing file as empty), appends t, and writes the result back as JSON.
+func appendTranscript(path string, t mockexec.Transcript) error {
+	var transcripts []mockexec.Transcript
+
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &transcripts); err != nil {
+			return fmt.Errorf("parsing existing transcript %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	transcripts = append(transcripts, t)
+
+	data, err := json.MarshalIndent(transcripts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
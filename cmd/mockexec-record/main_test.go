@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"mock_exec/mockexec"
+)
+
+func Test_RunAndCapture_CapturesArgvStdoutExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("relies on a POSIX echo")
+	}
+
+	tr, err := runAndCapture("echo", []string{"hello"})
+	if err != nil {
+		t.Fatalf("runAndCapture() failed: %v", err)
+	}
+	if tr.Stdout != "hello\n" || tr.ExitCode != 0 {
+		t.Errorf("runAndCapture() = %+v, want Stdout %q and ExitCode 0", tr, "hello\n")
+	}
+}
+
+func Test_AppendTranscript_AppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transcript.json")
+
+	first := mockexec.Transcript{Name: "git", Args: []string{"status"}, Stdout: "clean\n"}
+	if err := appendTranscript(path, first); err != nil {
+		t.Fatalf("appendTranscript() first call failed: %v", err)
+	}
+	second := mockexec.Transcript{Name: "git", Args: []string{"log"}, Stdout: "deadbeef\n"}
+	if err := appendTranscript(path, second); err != nil {
+		t.Fatalf("appendTranscript() second call failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read transcript file: %v", err)
+	}
+	for _, want := range []string{"clean", "deadbeef"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("transcript file missing %q entry:\n%s", want, data)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mock_exec/mockexec"
+)
+
+// generate renders one ExpectCommand(...).Return(...) statement per
+// transcript, in recorded order, against the *mockexec.MockCommander named
+// varName. A non-zero ExitCode renders as mockexec.ExitError(code, stderr)
+// rather than a bare error, so the generated expectation reproduces the
+// same error shape the real invocation failed with.
+func generate(transcripts []mockexec.Transcript, varName string) string {
+	var b strings.Builder
+	for _, t := range transcripts {
+		args := make([]string, 0, len(t.Args))
+		for _, a := range t.Args {
+			args = append(args, strconv.Quote(a))
+		}
+
+		errExpr := "nil"
+		if t.ExitCode != 0 {
+			errExpr = fmt.Sprintf("mockexec.ExitError(%d, []byte(%s))", t.ExitCode, strconv.Quote(t.Stderr))
+		}
+
+		fmt.Fprintf(&b, "%s.ExpectCommand(%s%s).Return(%s, %s)\n",
+			varName, strconv.Quote(t.Name), argsSuffix(args), strconv.Quote(t.Stdout), errExpr)
+	}
+	return b.String()
+}
+
+// argsSuffix renders args as ", a1, a2, ..." for appending after the
+// command name in an ExpectCommand call, or "" if there are none.
+func argsSuffix(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(args, ", ")
+}
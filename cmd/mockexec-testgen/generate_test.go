@@ -0,0 +1,48 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"mock_exec/mockexec"
+)
+
+func Test_Generate_EmitsOneExpectationPerTranscript(t *testing.T) {
+	src := generate([]mockexec.Transcript{
+		{Name: "git", Args: []string{"rev-parse", "--abbrev-ref", "HEAD"}, Stdout: "main\n"},
+		{Name: "git", Args: []string{"push"}, Stderr: "! [rejected]\n", ExitCode: 1},
+	}, "mc")
+
+	for _, want := range []string{
+		`mc.ExpectCommand("git", "rev-parse", "--abbrev-ref", "HEAD").Return("main\n", nil)`,
+		`mc.ExpectCommand("git", "push").Return("", mockexec.ExitError(1, []byte("! [rejected]\n")))`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generate() missing statement %q, got:\n%s", want, src)
+		}
+	}
+}
+
+func Test_Generate_OutputParsesAsValidGo(t *testing.T) {
+	src := generate([]mockexec.Transcript{
+		{Name: "echo", Args: []string{"hi"}, Stdout: "hi\n"},
+	}, "mc")
+
+	wrapped := "package p\nfunc f() {\n" + src + "}\n"
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "generated.go", wrapped, 0); err != nil {
+		t.Fatalf("generated statement does not parse: %v\n%s", err, wrapped)
+	}
+}
+
+func Test_Generate_NoArgs_OmitsTrailingComma(t *testing.T) {
+	src := generate([]mockexec.Transcript{
+		{Name: "pwd", Stdout: "/home\n"},
+	}, "mc")
+
+	if !strings.Contains(src, `mc.ExpectCommand("pwd").Return("/home\n", nil)`) {
+		t.Errorf("generate() = %q, want a no-args ExpectCommand call", src)
+	}
+}
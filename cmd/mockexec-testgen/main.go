@@ -0,0 +1,43 @@
+// Command mockexec-testgen converts a transcript recorded by mockexec-record
+// (or TranscriptRecorder.Save) into ready-to-paste Go code using mockexec's
+// expectation DSL - one ExpectCommand(...).Return(...) statement per
+// recorded call - so migrating a manual test run into an automated test is
+// mechanical instead of hand-transcribed.
+//
+// Usage:
+//
+//	mockexec-testgen -in transcript.json -var mc
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"mock_exec/mockexec"
+)
+
+func main() {
+	in := flag.String("in", "", "transcript file to read (required)")
+	varName := flag.String("var", "mc", "name of the *mockexec.MockCommander variable in the generated code")
+	flag.Parse()
+
+	if *in == "" {
+		fmt.Fprintln(os.Stderr, "mockexec-testgen: -in is required")
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "mockexec-testgen:", err)
+		os.Exit(1)
+	}
+	var transcripts []mockexec.Transcript
+	if err := json.Unmarshal(data, &transcripts); err != nil {
+		fmt.Fprintln(os.Stderr, "mockexec-testgen:", err)
+		os.Exit(1)
+	}
+
+	fmt.Print(generate(transcripts, *varName))
+}
@@ -0,0 +1,88 @@
+// Command mockexec-transcript-diff reports added, removed, and changed
+// invocations between two transcripts recorded by mockexec-record (or
+// TranscriptRecorder.Save), optionally ignoring volatile content like
+// timestamps or temp paths, for reviewing behavior changes across release
+// branches without diffing two full JSON files by eye.
+//
+// Usage:
+//
+//	mockexec-transcript-diff -ignore '\d{4}-\d{2}-\d{2}T\S+' before.json after.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+
+	"mock_exec/mockexec"
+)
+
+type ignoreFlags []*regexp.Regexp
+
+func (f *ignoreFlags) String() string { return "" }
+
+func (f *ignoreFlags) Set(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, re)
+	return nil
+}
+
+func main() {
+	var ignore ignoreFlags
+	flag.Var(&ignore, "ignore", "regexp matching volatile content to ignore; may be repeated")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "mockexec-transcript-diff: usage: mockexec-transcript-diff [-ignore pattern]... <a.json> <b.json>")
+		os.Exit(2)
+	}
+
+	a, err := loadTranscripts(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "mockexec-transcript-diff:", err)
+		os.Exit(1)
+	}
+	b, err := loadTranscripts(args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "mockexec-transcript-diff:", err)
+		os.Exit(1)
+	}
+
+	diffs := mockexec.DiffTranscripts(a, b, mockexec.DiffOptions{IgnorePatterns: ignore})
+	for _, d := range diffs {
+		fmt.Println(formatDiff(d))
+	}
+	if len(diffs) > 0 {
+		os.Exit(1)
+	}
+}
+
+func loadTranscripts(path string) ([]mockexec.Transcript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var transcripts []mockexec.Transcript
+	if err := json.Unmarshal(data, &transcripts); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return transcripts, nil
+}
+
+func formatDiff(d mockexec.InvocationDiff) string {
+	switch d.Kind {
+	case mockexec.DiffAdded:
+		return fmt.Sprintf("+ [%d] %s %v", d.Index, d.B.Name, d.B.Args)
+	case mockexec.DiffRemoved:
+		return fmt.Sprintf("- [%d] %s %v", d.Index, d.A.Name, d.A.Args)
+	default:
+		return fmt.Sprintf("~ [%d] %s %v: stdout %q -> %q, exitCode %d -> %d",
+			d.Index, d.A.Name, d.A.Args, d.A.Stdout, d.B.Stdout, d.A.ExitCode, d.B.ExitCode)
+	}
+}
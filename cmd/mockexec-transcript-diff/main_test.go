@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"mock_exec/mockexec"
+)
+
+func Test_LoadTranscripts_ReadsJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transcript.json")
+	const data = `[{"name":"git","args":["status"],"stdout":"clean\n","stderr":"","exitCode":0}]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("failed to write transcript file: %v", err)
+	}
+
+	transcripts, err := loadTranscripts(path)
+	if err != nil {
+		t.Fatalf("loadTranscripts() failed: %v", err)
+	}
+	if len(transcripts) != 1 || transcripts[0].Name != "git" {
+		t.Errorf("loadTranscripts() = %+v, want one git transcript", transcripts)
+	}
+}
+
+func Test_FormatDiff_RendersEachKind(t *testing.T) {
+	added := mockexec.InvocationDiff{Index: 2, Kind: mockexec.DiffAdded, B: &mockexec.Transcript{Name: "git", Args: []string{"push"}}}
+	if got := formatDiff(added); got == "" {
+		t.Error("formatDiff() for an added diff returned empty string")
+	}
+
+	removed := mockexec.InvocationDiff{Index: 1, Kind: mockexec.DiffRemoved, A: &mockexec.Transcript{Name: "git", Args: []string{"log"}}}
+	if got := formatDiff(removed); got == "" {
+		t.Error("formatDiff() for a removed diff returned empty string")
+	}
+
+	changed := mockexec.InvocationDiff{
+		Index: 0, Kind: mockexec.DiffChanged,
+		A: &mockexec.Transcript{Name: "git", Args: []string{"status"}, Stdout: "clean\n"},
+		B: &mockexec.Transcript{Name: "git", Args: []string{"status"}, Stdout: "dirty\n"},
+	}
+	if got := formatDiff(changed); got == "" {
+		t.Error("formatDiff() for a changed diff returned empty string")
+	}
+}
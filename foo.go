@@ -2,33 +2,27 @@ package foo
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
-)
-
-type IShellCommand interface {
-	SetDir(string)
-	Output() ([]byte, error)
-	Wait() error
-}
 
-type execShellCommand struct {
-	*exec.Cmd
-}
+	"mock_exec/mockexec"
+)
 
-func (exc execShellCommand) SetDir(dir string) {
-	exc.Dir = dir
-}
+// override this in tests to mock the git shell command
+var shellCommander mockexec.ShellCommanderFunc = mockexec.NewExecShellCommander
 
-func newExecShellCommander(name string, arg ...string) IShellCommand {
-	execCmd := exec.Command(name, arg...)
-	return execShellCommand{Cmd: execCmd}
-}
+// override this in tests to mock the git shell command when a context is needed,
+// e.g. to test timeout/cancellation paths
+var shellCommanderContext mockexec.ShellCommanderContextFunc = mockexec.NewExecShellCommanderContext
 
-// override this in tests to mock the git shell command
-var shellCommander = newExecShellCommander
+// override this in tests to mock whether/where the git binary is found
+var lookPath mockexec.LookPathFunc = mockexec.NewExecLookPath
 
 func myFuncThatUsesExecCmd() {
+	if _, err := lookPath("git"); err != nil {
+		fmt.Println("git is not installed")
+		return
+	}
+
 	cmd := shellCommander("git", "rev-parse", "--abbrev-ref", "HEAD")
 	cmd.SetDir("mydir")
 	output, err := cmd.Output()
@@ -0,0 +1,51 @@
+package mockexec
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_MockShellCommand_CancelCalledOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sc := &MockShellCommand{ctx: ctx, Stdout: []byte("ok")}
+
+	cancelCalled := make(chan struct{})
+	sc.SetCancel(func() error {
+		close(cancelCalled)
+		return nil
+	})
+
+	cancel()
+
+	if _, err := sc.Output(); err == nil {
+		t.Error("expected Output() to return the context's error once canceled")
+	}
+
+	select {
+	case <-cancelCalled:
+	default:
+		t.Error("expected the function set via SetCancel to have been called")
+	}
+	if !sc.CancelCalled() {
+		t.Error("expected CancelCalled() to report true")
+	}
+}
+
+func Test_MockShellCommand_WaitDelayKillsAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sc := &MockShellCommand{ctx: ctx, Stdout: []byte("ok")}
+	sc.SetCancel(func() error { return nil })
+	sc.SetWaitDelay(10 * time.Millisecond)
+
+	cancel()
+
+	start := time.Now()
+	sc.Output()
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Output() returned after %v, want at least the configured WaitDelay", elapsed)
+	}
+	if !sc.killed {
+		t.Error("expected the process to have been killed after WaitDelay elapsed")
+	}
+}
@@ -0,0 +1,79 @@
+package mockexec
+
+import "testing"
+
+func Test_Expectation_Times_PassesOnExactCount(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "fetch").Return("", nil).Times(2)
+
+	mc.Commander("git", "fetch")
+	mc.Commander("git", "fetch")
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if spy.Failed() {
+		t.Error("expected Verify to pass when called exactly the Times(2) count")
+	}
+}
+
+func Test_Expectation_Times_FailsOnWrongCount(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "fetch").Return("", nil).Times(2)
+
+	mc.Commander("git", "fetch")
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("expected Verify to fail when call count doesn't match Times(2)")
+	}
+}
+
+func Test_Expectation_AtLeastOnce_FailsWhenUncalled(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "status").Return("", nil).AtLeastOnce()
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("expected Verify to fail when AtLeastOnce expectation is never called")
+	}
+}
+
+func Test_Expectation_AtMost_FailsWhenOverCalled(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "push").Return("", nil).AtMost(1)
+
+	mc.Commander("git", "push")
+	mc.Commander("git", "push")
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("expected Verify to fail when call count exceeds AtMost(1)")
+	}
+}
+
+func Test_Expectation_Never_PassesWhenUncalled(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "push", "--force").Return("", nil).Never()
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if spy.Failed() {
+		t.Error("expected Verify to pass when a Never() expectation is never called")
+	}
+}
+
+func Test_Expectation_Never_FailsWhenCalled(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "push", "--force").Return("", nil).Never()
+
+	mc.Commander("git", "push", "--force")
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("expected Verify to fail when a Never() expectation was called")
+	}
+}
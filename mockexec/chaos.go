@@ -0,0 +1,107 @@
+package mockexec
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ChaosConfig rates are independent probabilities, each in [0, 1], checked
+// on every invocation: a single call can be both delayed and have its
+// output truncated, for example.
+type ChaosConfig struct {
+	// FailureRate is the chance an invocation fails with FailureErr (or a
+	// generic exit-1 ExitError, if FailureErr is nil) instead of running
+	// the wrapped commander at all.
+	FailureRate float64
+	FailureErr  error
+
+	// DelayRate is the chance an invocation sleeps for DelayAmount before
+	// producing its response.
+	DelayRate   float64
+	DelayAmount time.Duration
+
+	// TruncateRate is the chance an invocation's stdout is cut off
+	// mid-stream via TruncateMidLine, at a random length between 0 and the
+	// real length.
+	TruncateRate float64
+}
+
+// ChaosCommander wraps a ShellCommanderFunc with a seeded math/rand source
+// that randomly injects failures, delays, or truncated output at
+// configurable rates, for soak-testing retry and cleanup logic against
+// subprocess flakiness that's otherwise hard to reproduce. The seed makes a
+// run reproducible: the same seed and the same sequence of calls always
+// injects the same chaos.
+type ChaosCommander struct {
+	next   ShellCommanderFunc
+	config ChaosConfig
+
+	// mu guards rng, since math/rand.Rand is documented as unsafe for
+	// concurrent use and Commander is meant to be called from concurrent
+	// workers - that's the whole point of chaos-testing a worker pool.
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewChaosCommander wraps next, drawing chaos decisions from a math/rand
+// source seeded with seed.
+func NewChaosCommander(next ShellCommanderFunc, config ChaosConfig, seed int64) *ChaosCommander {
+	return &ChaosCommander{next: next, config: config, rng: rand.New(rand.NewSource(seed))}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (cc *ChaosCommander) Commander(name string, arg ...string) IShellCommand {
+	if cc.roll(cc.config.FailureRate) {
+		err := cc.config.FailureErr
+		if err == nil {
+			err = ExitError(1, nil)
+		}
+		return &MockShellCommand{Err: err, ExitCodeValue: 1}
+	}
+
+	cmd := cc.next(name, arg...)
+	delay := cc.roll(cc.config.DelayRate)
+	truncate := cc.roll(cc.config.TruncateRate)
+	if !delay && !truncate {
+		return cmd
+	}
+
+	return &MockShellCommand{
+		OutputterFunc: func() ([]byte, error) {
+			if delay {
+				time.Sleep(cc.config.DelayAmount)
+			}
+			out, err := cmd.Output()
+			if truncate && len(out) > 0 {
+				out = []byte(TruncateMidLine(string(out), cc.randIntn(len(out))))
+			}
+			return out, err
+		},
+	}
+}
+
+// roll reports whether a [0, 1) draw from cc.rng falls below rate, treating
+// rate <= 0 as "never".
+func (cc *ChaosCommander) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	return cc.randFloat64() < rate
+}
+
+// randFloat64 draws from cc.rng under mu, since math/rand.Rand isn't safe
+// for concurrent use.
+func (cc *ChaosCommander) randFloat64() float64 {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.rng.Float64()
+}
+
+// randIntn draws from cc.rng under mu, since math/rand.Rand isn't safe for
+// concurrent use.
+func (cc *ChaosCommander) randIntn(n int) int {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.rng.Intn(n)
+}
@@ -0,0 +1,86 @@
+package mockexec
+
+import (
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_ChaosCommander_FailureRateOne_AlwaysFails(t *testing.T) {
+	cc := NewChaosCommander(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("ok\n")}
+	}, ChaosConfig{FailureRate: 1}, 1)
+
+	_, err := cc.Commander("echo", "hi").Output()
+	if _, ok := err.(*exec.ExitError); !ok {
+		t.Errorf("Output() err = %v, want *exec.ExitError", err)
+	}
+}
+
+func Test_ChaosCommander_ZeroRates_PassesThroughUnchanged(t *testing.T) {
+	cc := NewChaosCommander(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("ok\n")}
+	}, ChaosConfig{}, 1)
+
+	out, err := cc.Commander("echo", "hi").Output()
+	if err != nil || string(out) != "ok\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "ok\n")
+	}
+}
+
+func Test_ChaosCommander_SameSeedSameCalls_ReproducesOutcome(t *testing.T) {
+	config := ChaosConfig{FailureRate: 0.5, DelayRate: 0.5, TruncateRate: 0.5}
+	next := func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("hello world\n")}
+	}
+
+	var results []string
+	for run := 0; run < 2; run++ {
+		cc := NewChaosCommander(next, config, 42)
+		var outcomes []string
+		for i := 0; i < 10; i++ {
+			out, err := cc.Commander("echo", "hi").Output()
+			if err != nil {
+				outcomes = append(outcomes, "err")
+			} else {
+				outcomes = append(outcomes, string(out))
+			}
+		}
+		results = append(results, outcomes...)
+	}
+
+	for i := 0; i < 10; i++ {
+		if results[i] != results[i+10] {
+			t.Errorf("call %d diverged across runs with the same seed: %q vs %q", i, results[i], results[i+10])
+		}
+	}
+}
+
+func Test_ChaosCommander_DelayRateOne_Sleeps(t *testing.T) {
+	cc := NewChaosCommander(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("ok\n")}
+	}, ChaosConfig{DelayRate: 1, DelayAmount: 20 * time.Millisecond}, 1)
+
+	start := time.Now()
+	cc.Commander("echo", "hi").Output()
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Output() returned after %v, want at least the configured delay", elapsed)
+	}
+}
+
+func Test_ChaosCommander_ConcurrentCommander_NoDataRace(t *testing.T) {
+	cc := NewChaosCommander(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("hello world\n")}
+	}, ChaosConfig{FailureRate: 0.5, DelayRate: 0.5, TruncateRate: 0.5}, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cc.Commander("echo", "hi").Output()
+		}()
+	}
+	wg.Wait()
+}
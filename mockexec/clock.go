@@ -0,0 +1,21 @@
+package mockexec
+
+import "time"
+
+// Clock is the minimal subset of github.com/benbjohnson/clock.Clock that
+// mockexec needs to simulate durations. Any type satisfying it - including
+// a real benbjohnson/clock.Clock or its Mock - can be plugged in via
+// MockCommander.Clock, so scripted delays (see Expectation.After) advance
+// virtual time instead of sleeping for real and slowing down the test suite.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the default Clock, backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,56 @@
+package mockexec
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a minimal Clock implementation for tests: Sleep advances a
+// virtual "now" instantly instead of blocking, the way benbjohnson/clock's
+// Mock would once its own Add is called - here Sleep just does the advancing
+// itself, since nothing in this test needs independent control over When.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.Sleep(d)
+	ch <- c.Now()
+	return ch
+}
+
+func Test_MockCommander_FakeClock_DoesNotSleepForReal(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	mc := NewMockCommander()
+	mc.Clock = clock
+	mc.ExpectCommand("slow-tool").Return("done\n", nil).After(time.Hour)
+
+	start := time.Now()
+	out, err := mc.Commander("slow-tool").Output()
+	wallElapsed := time.Since(start)
+
+	if err != nil || string(out) != "done\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "done\n")
+	}
+	if wallElapsed >= time.Hour {
+		t.Fatalf("wall-clock elapsed = %v, want the fake clock to have absorbed the delay", wallElapsed)
+	}
+	if got := clock.Now().Sub(time.Unix(0, 0)); got != time.Hour {
+		t.Errorf("fake clock advanced by %v, want %v", got, time.Hour)
+	}
+}
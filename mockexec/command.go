@@ -0,0 +1,181 @@
+// Package mockexec provides a mockable abstraction over os/exec so that
+// application code which shells out can be unit tested without actually
+// running external processes. See the package README (and the example in
+// this module's root foo.go/foo_test.go) for the intended usage pattern:
+// depend on IShellCommand and a package-level commander var, then swap the
+// commander for a mock in tests.
+package mockexec
+
+//go:generate go run go.uber.org/mock/mockgen -source=command.go -destination=gomock_shell_command.go -package=mockexec
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// IShellCommand is the subset of *exec.Cmd's API that application code is
+// expected to need. Extend it (and ExecShellCommand plus MockShellCommand)
+// as more of the exec.Cmd surface needs to be exercised from tests.
+type IShellCommand interface {
+	SetDir(string)
+	Output() ([]byte, error)
+	CombinedOutput() ([]byte, error)
+	Run() error
+	Start() error
+	Wait() error
+	StdoutPipe() (io.ReadCloser, error)
+	StderrPipe() (io.ReadCloser, error)
+	StdinPipe() (io.WriteCloser, error)
+	SetEnv([]string)
+	Environ() []string
+	SetStdin(io.Reader)
+	SetStdout(io.Writer)
+	SetStderr(io.Writer)
+	ExitCode() int
+	Kill() error
+	Signal(os.Signal) error
+
+	// Pid returns the process ID, mirroring exec.Cmd.Process.Pid, so code
+	// that stores a PID for later signaling can be driven from a started
+	// IShellCommand alone.
+	Pid() int
+
+	// ProcessState mirrors exec.Cmd.ProcessState: it's nil until Wait
+	// completes, and then exposes the exit status and basic CPU accounting.
+	ProcessState() ProcessState
+
+	// SetCancel and SetWaitDelay mirror exec.Cmd's Cancel and WaitDelay
+	// fields (Go 1.20): Cancel is called if the command's context is done
+	// before the process exits, and WaitDelay bounds how long Wait gives
+	// the process to exit on its own afterward before killing it.
+	SetCancel(func() error)
+	SetWaitDelay(time.Duration)
+
+	// SetSysProcAttr mirrors exec.Cmd.SysProcAttr, for code that configures
+	// OS-specific process attributes (process groups, credentials, etc.)
+	// before starting a command.
+	SetSysProcAttr(*syscall.SysProcAttr)
+
+	// SetExtraFiles mirrors exec.Cmd.ExtraFiles: additional open files made
+	// available to the child as descriptors 3, 4, and so on, in order.
+	SetExtraFiles([]*os.File)
+
+	// LookupErr mirrors exec.Cmd.Err (Go 1.19): a LookPath failure found
+	// while constructing the command is recorded here instead of being
+	// returned immediately, and only surfaces once Start/Run/Output/
+	// CombinedOutput is called, matching how a later SetDir/SetEnv/etc.
+	// call still runs against a command that's already "doomed" to fail.
+	LookupErr() error
+}
+
+// ExecShellCommand is the IShellCommand implementation backed by a real
+// *exec.Cmd. It is what NewExecShellCommander and NewExecShellCommanderContext
+// hand back, and is what runs when a package hasn't swapped in a mock.
+type ExecShellCommand struct {
+	*exec.Cmd
+}
+
+func (exc ExecShellCommand) SetDir(dir string) {
+	exc.Dir = dir
+}
+
+func (exc ExecShellCommand) SetEnv(env []string) {
+	exc.Env = env
+}
+
+func (exc ExecShellCommand) Environ() []string {
+	return exc.Env
+}
+
+func (exc ExecShellCommand) SetStdin(r io.Reader) {
+	exc.Stdin = r
+}
+
+func (exc ExecShellCommand) SetStdout(w io.Writer) {
+	exc.Stdout = w
+}
+
+func (exc ExecShellCommand) SetStderr(w io.Writer) {
+	exc.Stderr = w
+}
+
+// ExitCode returns the exit code of the exited process, or -1 if the process
+// hasn't exited or was terminated by a signal, matching os.ProcessState.ExitCode().
+func (exc ExecShellCommand) ExitCode() int {
+	if exc.Cmd.ProcessState == nil {
+		return -1
+	}
+	return exc.Cmd.ProcessState.ExitCode()
+}
+
+func (exc ExecShellCommand) Kill() error {
+	return exc.Process.Kill()
+}
+
+func (exc ExecShellCommand) Signal(sig os.Signal) error {
+	return exc.Process.Signal(sig)
+}
+
+// Pid returns -1 if the process hasn't started yet, since exc.Process is nil
+// until Start and has no Pid to read.
+func (exc ExecShellCommand) Pid() int {
+	if exc.Process == nil {
+		return -1
+	}
+	return exc.Process.Pid
+}
+
+// ProcessState returns nil until Wait has completed, since exc.ProcessState
+// is nil until then and *os.ProcessState's methods don't tolerate a nil
+// receiver.
+func (exc ExecShellCommand) ProcessState() ProcessState {
+	if exc.Cmd.ProcessState == nil {
+		return nil
+	}
+	return exc.Cmd.ProcessState
+}
+
+func (exc ExecShellCommand) SetCancel(cancel func() error) {
+	exc.Cancel = cancel
+}
+
+func (exc ExecShellCommand) SetWaitDelay(d time.Duration) {
+	exc.WaitDelay = d
+}
+
+func (exc ExecShellCommand) SetSysProcAttr(attr *syscall.SysProcAttr) {
+	exc.SysProcAttr = attr
+}
+
+func (exc ExecShellCommand) SetExtraFiles(files []*os.File) {
+	exc.ExtraFiles = files
+}
+
+// LookupErr returns the Cmd.Err recorded when this command was constructed
+// (e.g. a LookPath failure), if any. It's surfaced by Start/Run/Output/
+// CombinedOutput automatically, the same as a real *exec.Cmd; callers only
+// need this to inspect it ahead of time.
+func (exc ExecShellCommand) LookupErr() error {
+	return exc.Cmd.Err
+}
+
+// NewExecShellCommander is the default, real-exec-backed commander. Assign
+// it (or NewExecShellCommanderContext) to a package-level var in the
+// importing package so tests can swap in a mock.
+func NewExecShellCommander(name string, arg ...string) IShellCommand {
+	name, arg = wrapForShellScripts(name, arg)
+	execCmd := exec.Command(name, arg...)
+	return ExecShellCommand{Cmd: execCmd}
+}
+
+// NewExecShellCommanderContext is NewExecShellCommander's context-aware
+// counterpart, backed by exec.CommandContext.
+func NewExecShellCommanderContext(ctx context.Context, name string, arg ...string) IShellCommand {
+	name, arg = wrapForShellScripts(name, arg)
+	execCmd := exec.CommandContext(ctx, name, arg...)
+	return ExecShellCommand{Cmd: execCmd}
+}
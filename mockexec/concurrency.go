@@ -0,0 +1,97 @@
+package mockexec
+
+import "sync"
+
+// ConcurrencyTracker wraps a ShellCommanderFunc, recording how many
+// commands it handed out are simultaneously "running" - between Start and
+// Wait, or for the duration of Output/CombinedOutput/Run, which start and
+// wait internally - so AssertMaxConcurrent can verify a worker pool never
+// ran more than N subprocesses at once.
+type ConcurrencyTracker struct {
+	next ShellCommanderFunc
+
+	mu      sync.Mutex
+	running int
+	maxSeen int
+}
+
+// NewConcurrencyTracker returns a ConcurrencyTracker delegating to next for
+// the actual IShellCommand behavior.
+func NewConcurrencyTracker(next ShellCommanderFunc) *ConcurrencyTracker {
+	return &ConcurrencyTracker{next: next}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (ct *ConcurrencyTracker) Commander(name string, args ...string) IShellCommand {
+	return &trackedShellCommand{IShellCommand: ct.next(name, args...), tracker: ct}
+}
+
+// MaxConcurrent returns the highest number of overlapping running windows
+// observed so far.
+func (ct *ConcurrencyTracker) MaxConcurrent() int {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	return ct.maxSeen
+}
+
+// AssertMaxConcurrent fails t if more commands were ever running at once
+// than n.
+func (ct *ConcurrencyTracker) AssertMaxConcurrent(t TestingT, n int) {
+	if got := ct.MaxConcurrent(); got > n {
+		t.Fatalf("mockexec: observed %d commands running concurrently, want at most %d", got, n)
+	}
+}
+
+func (ct *ConcurrencyTracker) enter() {
+	ct.mu.Lock()
+	ct.running++
+	if ct.running > ct.maxSeen {
+		ct.maxSeen = ct.running
+	}
+	ct.mu.Unlock()
+}
+
+func (ct *ConcurrencyTracker) leave() {
+	ct.mu.Lock()
+	ct.running--
+	ct.mu.Unlock()
+}
+
+// trackedShellCommand wraps an IShellCommand so every way of running it -
+// Start/Wait, or the self-contained Output/CombinedOutput/Run - opens and
+// closes a window on the tracker.
+type trackedShellCommand struct {
+	IShellCommand
+	tracker *ConcurrencyTracker
+}
+
+func (tc *trackedShellCommand) Start() error {
+	err := tc.IShellCommand.Start()
+	if err == nil {
+		tc.tracker.enter()
+	}
+	return err
+}
+
+func (tc *trackedShellCommand) Wait() error {
+	defer tc.tracker.leave()
+	return tc.IShellCommand.Wait()
+}
+
+func (tc *trackedShellCommand) Output() ([]byte, error) {
+	tc.tracker.enter()
+	defer tc.tracker.leave()
+	return tc.IShellCommand.Output()
+}
+
+func (tc *trackedShellCommand) CombinedOutput() ([]byte, error) {
+	tc.tracker.enter()
+	defer tc.tracker.leave()
+	return tc.IShellCommand.CombinedOutput()
+}
+
+func (tc *trackedShellCommand) Run() error {
+	tc.tracker.enter()
+	defer tc.tracker.leave()
+	return tc.IShellCommand.Run()
+}
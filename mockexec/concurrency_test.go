@@ -0,0 +1,70 @@
+package mockexec
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_ConcurrencyTracker_TracksOverlappingRuns(t *testing.T) {
+	ct := NewConcurrencyTracker(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Delay: 20 * time.Millisecond}
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ct.Commander("sleep", "1").Output()
+		}()
+	}
+	wg.Wait()
+
+	if got := ct.MaxConcurrent(); got != 3 {
+		t.Errorf("MaxConcurrent() = %d, want 3", got)
+	}
+
+	fake := &fakeTestingT{}
+	ct.AssertMaxConcurrent(fake, 2)
+	if !fake.failed {
+		t.Error("AssertMaxConcurrent(2) should fail when 3 ran concurrently")
+	}
+}
+
+func Test_ConcurrencyTracker_SequentialRunsStayAtOne(t *testing.T) {
+	ct := NewConcurrencyTracker(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{}
+	})
+
+	for i := 0; i < 5; i++ {
+		ct.Commander("echo", "hi").Output()
+	}
+
+	if got := ct.MaxConcurrent(); got != 1 {
+		t.Errorf("MaxConcurrent() = %d, want 1 for sequential calls", got)
+	}
+
+	fake := &fakeTestingT{}
+	ct.AssertMaxConcurrent(fake, 1)
+	if fake.failed {
+		t.Error("AssertMaxConcurrent(1) should pass when calls never overlapped")
+	}
+}
+
+func Test_ConcurrencyTracker_StartWaitWindow(t *testing.T) {
+	ct := NewConcurrencyTracker(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{}
+	})
+
+	cmd := ct.Commander("sleep", "1")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	if got := ct.MaxConcurrent(); got != 1 {
+		t.Errorf("MaxConcurrent() after Start() = %d, want 1", got)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("Wait() failed: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package mockexec
+
+import "context"
+
+// A package-level commander var (the pattern used elsewhere in this
+// library) races when subtests call t.Parallel(), since they all mutate
+// the same var concurrently. For that case, thread the commander through a
+// context.Context instead: each parallel (sub)test gets its own ctx, so
+// each can carry its own commander with no shared mutable state.
+
+type commanderContextKey struct{}
+
+// WithCommander returns a context carrying commander, to be retrieved later
+// with CommanderFromContext or CommandFromContext.
+func WithCommander(ctx context.Context, commander ShellCommanderFunc) context.Context {
+	return context.WithValue(ctx, commanderContextKey{}, commander)
+}
+
+// CommanderFromContext retrieves the commander installed by WithCommander,
+// if any.
+func CommanderFromContext(ctx context.Context) (ShellCommanderFunc, bool) {
+	commander, ok := ctx.Value(commanderContextKey{}).(ShellCommanderFunc)
+	return commander, ok
+}
+
+// CommandFromContext runs name/args through the commander carried by ctx,
+// falling back to defaultCommander if ctx carries none. Application code
+// that wants to be both production-correct and parallel-test-safe should
+// call this instead of a bare package-level commander var.
+func CommandFromContext(ctx context.Context, defaultCommander ShellCommanderFunc, name string, arg ...string) IShellCommand {
+	if commander, ok := CommanderFromContext(ctx); ok {
+		return commander(name, arg...)
+	}
+	return defaultCommander(name, arg...)
+}
@@ -0,0 +1,26 @@
+package mockexec
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_CommandFromContext_IsParallelSafe(t *testing.T) {
+	fallback := NewMockShellCommanderForOutput("fallback", nil, t)
+
+	for _, want := range []string{"one", "two", "three"} {
+		want := want
+		t.Run(want, func(t *testing.T) {
+			t.Parallel()
+			ctx := WithCommander(context.Background(), NewMockShellCommanderForOutput(want, nil, t))
+
+			out, err := CommandFromContext(ctx, fallback, "git", "status").Output()
+			if err != nil {
+				t.Fatalf("Output() failed: %v", err)
+			}
+			if string(out) != want {
+				t.Errorf("Output() = %q, want %q", out, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package mockexec
+
+import "strings"
+
+// TruncateMidLine cuts s after n bytes, simulating a subprocess whose
+// output pipe closed (or was killed) partway through writing a line, so a
+// parser expecting a complete line or a full JSON object instead sees a
+// cut-off fragment.
+func TruncateMidLine(s string, n int) string {
+	if n >= len(s) {
+		return s
+	}
+	return s[:n]
+}
+
+// InjectInvalidUTF8 appends a byte sequence that is not valid UTF-8 to s,
+// simulating a subprocess that writes raw/binary bytes into what a caller
+// normally treats as a text stream.
+func InjectInvalidUTF8(s string) string {
+	return s + "\xff\xfe"
+}
+
+// DropTrailingNewline removes one trailing "\n" from s, if present,
+// simulating a subprocess whose last line wasn't newline-terminated.
+func DropTrailingNewline(s string) string {
+	return strings.TrimSuffix(s, "\n")
+}
+
+// Corrupt passes the most recently configured response (via Return or
+// ReturnOnce) through fn before it's returned, so tests can exercise how a
+// parser handles malformed subprocess output - truncated mid-line, invalid
+// UTF-8, a missing trailing newline - without hand-rolling the corruption
+// at every call site. Combine with TruncateMidLine, InjectInvalidUTF8, or
+// DropTrailingNewline, or supply a custom fn.
+func (e *Expectation) Corrupt(fn func(string) string) *Expectation {
+	if e.lastIsTerminal {
+		e.terminal.corrupt = fn
+	} else if len(e.queue) > 0 {
+		e.queue[len(e.queue)-1].corrupt = fn
+	}
+	return e
+}
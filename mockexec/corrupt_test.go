@@ -0,0 +1,62 @@
+package mockexec
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func Test_TruncateMidLine(t *testing.T) {
+	if got := TruncateMidLine("hello world", 5); got != "hello" {
+		t.Errorf("TruncateMidLine() = %q, want %q", got, "hello")
+	}
+	if got := TruncateMidLine("short", 99); got != "short" {
+		t.Errorf("TruncateMidLine() with n beyond length = %q, want unchanged %q", got, "short")
+	}
+}
+
+func Test_InjectInvalidUTF8(t *testing.T) {
+	got := InjectInvalidUTF8("hello")
+	if utf8.ValidString(got) {
+		t.Errorf("InjectInvalidUTF8(%q) = %q, want invalid UTF-8", "hello", got)
+	}
+}
+
+func Test_DropTrailingNewline(t *testing.T) {
+	if got := DropTrailingNewline("line\n"); got != "line" {
+		t.Errorf("DropTrailingNewline() = %q, want %q", got, "line")
+	}
+	if got := DropTrailingNewline("no newline"); got != "no newline" {
+		t.Errorf("DropTrailingNewline() on string without newline = %q, want unchanged", got)
+	}
+}
+
+func Test_Expectation_Corrupt_AppliesToReturn(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("cat", "file.json").
+		Return(`{"ok": true}`, nil).
+		Corrupt(func(s string) string { return TruncateMidLine(s, 5) })
+
+	out, err := mc.Commander("cat", "file.json").Output()
+	want := `{"ok"`
+	if err != nil || string(out) != want {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, want)
+	}
+}
+
+func Test_Expectation_Corrupt_AppliesToReturnOnce(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("cat", "file.txt").
+		ReturnOnce("line\n", nil).
+		Corrupt(DropTrailingNewline).
+		Return("line\n", nil)
+
+	first, _ := mc.Commander("cat", "file.txt").Output()
+	if string(first) != "line" {
+		t.Errorf("first call Output() = %q, want %q (corrupted)", first, "line")
+	}
+
+	second, _ := mc.Commander("cat", "file.txt").Output()
+	if string(second) != "line\n" {
+		t.Errorf("second call Output() = %q, want %q (not corrupted)", second, "line\n")
+	}
+}
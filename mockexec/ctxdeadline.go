@@ -0,0 +1,62 @@
+package mockexec
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// NewContextDeadlineCommand returns a MockShellCommand that reproduces what
+// a real, exec.CommandContext-backed command returns when ctx's deadline
+// expires mid-run: Output/CombinedOutput/Run/Wait block until ctx is Done,
+// then the process is marked killed and they return an error that both
+// wraps an *exec.ExitError, found with errors.As rather than a direct type
+// assertion (the process was killed, not merely cancelled up front), and
+// satisfies errors.Is(err, ctx.Err()), with
+// ProcessState reporting the killed process - the signals application code
+// typically checks to disambiguate "the command was slow" from "the
+// command failed" on its own.
+func NewContextDeadlineCommand(ctx context.Context) *MockShellCommand {
+	block := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(block)
+	}()
+
+	sc := &MockShellCommand{BlockUntil: block}
+	respond := func() error {
+		sc.Kill()
+		exitErr, err := killedExitError()
+		if err != nil {
+			return ctx.Err()
+		}
+		sc.ExitCodeValue = exitErr.ExitCode()
+		sc.ProcessStateValue = exitErr.ProcessState
+		return fmt.Errorf("%w: %w", exitErr, ctx.Err())
+	}
+	sc.OutputterFunc = func() ([]byte, error) { return nil, respond() }
+	sc.WaiterFunc = respond
+	return sc
+}
+
+// killedExitError fabricates a real *exec.ExitError for a process killed by
+// SIGKILL (SIGKILL has no equivalent on Windows, so a plain non-zero exit
+// stands in there instead), the same trick errors.go's ExitError uses for
+// a plain exit code, so ProcessState reflects "killed", not just "exited
+// non-zero".
+func killedExitError() (*exec.ExitError, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/c", "exit 1")
+	} else {
+		cmd = exec.Command("sh", "-c", "kill -KILL $$")
+	}
+
+	err := cmd.Run()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return nil, fmt.Errorf("mockexec: could not fabricate a killed process state")
+	}
+	return exitErr, nil
+}
@@ -0,0 +1,46 @@
+package mockexec
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func Test_NewContextDeadlineCommand_ReturnsKilledExitErrorWrappingCtxErr(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	cmd := NewContextDeadlineCommand(ctx)
+
+	start := time.Now()
+	_, err := cmd.Output()
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Output() returned after %v, want at least the context's deadline", elapsed)
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("Output() err = %v (%T), want it to wrap an *exec.ExitError", err, err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Output() err = %v, want errors.Is(err, context.DeadlineExceeded)", err)
+	}
+	if cmd.LastSignal() == nil {
+		t.Error("LastSignal() is nil, want the process to have been marked killed")
+	}
+}
+
+func Test_NewContextDeadlineCommand_WaitAlsoObservesDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	cmd := NewContextDeadlineCommand(ctx)
+	cmd.Start()
+	err := cmd.Wait()
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() err = %v, want errors.Is(err, context.DeadlineExceeded)", err)
+	}
+}
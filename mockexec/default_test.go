@@ -0,0 +1,35 @@
+package mockexec
+
+import "testing"
+
+func Test_MockCommander_DefaultReturn(t *testing.T) {
+	mc := NewMockCommander()
+	mc.DefaultReturn("fallback\n", nil)
+
+	out, err := mc.Commander("whatever", "args").Output()
+	if err != nil || string(out) != "fallback\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "fallback\n")
+	}
+}
+
+func Test_MockCommander_SetDefault(t *testing.T) {
+	mc := NewMockCommander()
+	mc.SetDefault(func(name string, args []string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte(name + " handled\n")}
+	})
+
+	out, err := mc.Commander("anything").Output()
+	if err != nil || string(out) != "anything handled\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "anything handled\n")
+	}
+}
+
+func Test_MockCommander_PanicsWithoutDefault(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for an unmatched command with no default configured")
+		}
+	}()
+	mc := NewMockCommander()
+	mc.Commander("anything")
+}
@@ -0,0 +1,49 @@
+package mockexec
+
+import (
+	"bufio"
+	"testing"
+)
+
+func Test_MockShellCommand_Dialogue_PromptThenAnswer(t *testing.T) {
+	cmd := &MockShellCommand{
+		Dialogue: []DialogueStep{
+			{Output: "Are you sure? [y/N] ", WaitForLine: true},
+			{Output: "Proceeding...\n"},
+		},
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatalf("StdinPipe() failed: %v", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("StdoutPipe() failed: %v", err)
+	}
+
+	reader := bufio.NewReader(stdout)
+	prompt := make([]byte, len("Are you sure? [y/N] "))
+	if _, err := reader.Read(prompt); err != nil {
+		t.Fatalf("reading prompt failed: %v", err)
+	}
+	if got, want := string(prompt), "Are you sure? [y/N] "; got != want {
+		t.Fatalf("prompt = %q, want %q", got, want)
+	}
+
+	if _, err := stdin.Write([]byte("y\n")); err != nil {
+		t.Fatalf("writing answer failed: %v", err)
+	}
+
+	rest, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("reading final output failed: %v", err)
+	}
+	if got, want := rest, "Proceeding...\n"; got != want {
+		t.Errorf("final output = %q, want %q", got, want)
+	}
+
+	if got, want := cmd.CapturedStdin(), "y\n"; got != want {
+		t.Errorf("CapturedStdin() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,13 @@
+package mockexec
+
+import "testing"
+
+// AssertDir fails t unless cmd's SetDir was called with want, the most
+// direct way to catch a wrapper that runs a command in the wrong directory
+// when the test isn't otherwise using MockCommander's WithDir.
+func AssertDir(t *testing.T, cmd *MockShellCommand, want string) {
+	t.Helper()
+	if got := cmd.Dir(); got != want {
+		t.Errorf("mockexec: command ran in dir %q, want %q", got, want)
+	}
+}
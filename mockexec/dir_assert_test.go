@@ -0,0 +1,23 @@
+package mockexec
+
+import "testing"
+
+func Test_AssertDir(t *testing.T) {
+	cmd := &MockShellCommand{Stdout: []byte("ok\n")}
+	cmd.SetDir("mydir")
+
+	AssertDir(t, cmd, "mydir")
+}
+
+func Test_MockCommander_WithDir(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "status").Return("clean\n", nil).WithDir("mydir")
+
+	cmd := mc.Commander("git", "status")
+	cmd.SetDir("mydir")
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	mc.Verify(t)
+}
@@ -0,0 +1,63 @@
+package mockexec
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DryRunCommander is a ShellCommanderFunc provider that never executes
+// anything: it writes the fully-quoted command line to Writer (if set) and
+// returns a configurable, benign response. It's meant to back a CLI's
+// --dry-run flag as much as a test - the same type works as the commander
+// in production when the flag is set, and in a test asserting nothing
+// really ran.
+type DryRunCommander struct {
+	// Writer, if set, receives one line per invocation: the fully-quoted
+	// command line that would have run.
+	Writer io.Writer
+
+	// Output and Err are what every invocation's Output and CombinedOutput
+	// return, and Err is what its Run returns. Wait does not return Err.
+	Output string
+	Err    error
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (d *DryRunCommander) Commander(name string, arg ...string) IShellCommand {
+	if d.Writer != nil {
+		fmt.Fprintln(d.Writer, QuoteCommandLine(name, arg))
+	}
+	return &MockShellCommand{Stdout: []byte(d.Output), Err: d.Err}
+}
+
+// QuoteCommandLine renders name and args as a single shell-quotable string,
+// wrapping any argument containing whitespace or quotes in double quotes,
+// for diagnostics like DryRunCommander's lines and MockCommander's
+// unexpected-command panics.
+func QuoteCommandLine(name string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, quoteArg(name))
+	for _, a := range args {
+		parts = append(parts, quoteArg(a))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Quote is QuoteCommandLine's argv-slice convenience form: argv[0] is the
+// command name and argv[1:] its arguments, so a caller already holding a
+// single []string (e.g. straight from an ExpectCommand call or a captured
+// invocation) doesn't have to split it first.
+func Quote(argv []string) string {
+	if len(argv) == 0 {
+		return ""
+	}
+	return QuoteCommandLine(argv[0], argv[1:])
+}
+
+func quoteArg(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\r\"'") {
+		return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+	}
+	return s
+}
@@ -0,0 +1,41 @@
+package mockexec
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_DryRunCommander_PrintsAndDoesNotExecute(t *testing.T) {
+	var buf bytes.Buffer
+	d := &DryRunCommander{Writer: &buf, Output: "would have run"}
+
+	out, err := d.Commander("git", "push", "--force", "origin main").Output()
+	if err != nil || string(out) != "would have run" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "would have run")
+	}
+
+	want := `git push --force "origin main"` + "\n"
+	if buf.String() != want {
+		t.Errorf("Writer got %q, want %q", buf.String(), want)
+	}
+}
+
+func Test_QuoteCommandLine(t *testing.T) {
+	got := QuoteCommandLine("echo", []string{"hello world", "plain", `has"quote`})
+	want := `echo "hello world" plain "has\"quote"`
+	if got != want {
+		t.Errorf("QuoteCommandLine() = %q, want %q", got, want)
+	}
+}
+
+func Test_Quote(t *testing.T) {
+	got := Quote([]string{"git", "commit", "-m", "fix the thing"})
+	want := `git commit -m "fix the thing"`
+	if got != want {
+		t.Errorf("Quote() = %q, want %q", got, want)
+	}
+
+	if got := Quote(nil); got != "" {
+		t.Errorf("Quote(nil) = %q, want %q", got, "")
+	}
+}
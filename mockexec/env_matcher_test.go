@@ -0,0 +1,31 @@
+package mockexec
+
+import "testing"
+
+func Test_MockCommander_WithEnv(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("deploy").Return("ok\n", nil).WithEnv("STAGE=prod")
+
+	cmd := mc.Commander("deploy")
+	cmd.SetEnv([]string{"STAGE=prod", "DEBUG=0"})
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	mc.Verify(t)
+}
+
+func Test_MockCommander_WithEnvMatching(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("deploy").Return("ok\n", nil).WithEnvMatching(func(env []string) bool {
+		return len(env) == 2
+	})
+
+	cmd := mc.Commander("deploy")
+	cmd.SetEnv([]string{"STAGE=prod", "DEBUG=0"})
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	mc.Verify(t)
+}
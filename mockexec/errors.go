@@ -0,0 +1,87 @@
+package mockexec
+
+import (
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"runtime"
+)
+
+// NotFoundError builds an error with the same shape exec.LookPath produces
+// when a binary isn't on PATH: an *exec.Error wrapping exec.ErrNotFound, so
+// errors.Is(err, exec.ErrNotFound) succeeds in tests exactly as it would in
+// production.
+func NotFoundError(name string) error {
+	return &exec.Error{Name: name, Err: exec.ErrNotFound}
+}
+
+// ExitError builds a real *exec.ExitError with the given exit code and
+// stderr content, so tests that type-assert errors to *exec.ExitError and
+// read ExitCode()/Stderr exercise the same code path as production. It
+// works by actually running a trivial subprocess that exits with code, then
+// wrapping its (real) os.ProcessState. stderr is capped the same way
+// exec.Cmd.Output's own captured-stderr-on-error buffer is, via capStderr,
+// so a test feeding in unbounded stderr sees the same truncated shape
+// production code will.
+//
+// Because the underlying ProcessState comes from a real, just-exited
+// process, its platform-specific bits (a syscall.WaitStatus on Unix, a
+// plain exit code on Windows) are always correct for whatever OS the test
+// is running on - code is a logical, OS-agnostic exit code either way, so
+// a fixture recorded on one platform (see FixtureCommander) replays with
+// the right native status on any other.
+func ExitError(code int, stderr []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/c", fmt.Sprintf("exit %d", code))
+	} else {
+		cmd = exec.Command("sh", "-c", fmt.Sprintf("exit %d", code))
+	}
+
+	runErr := cmd.Run()
+	if cmd.ProcessState == nil {
+		// No shell available to fabricate a real ProcessState from; fall
+		// back to a plain error carrying the same message shape.
+		return fmt.Errorf("exit status %d", code)
+	}
+	if exitErr, ok := runErr.(*exec.ExitError); ok {
+		exitErr.Stderr = capStderr(stderr)
+		return exitErr
+	}
+	// code was 0: there's no *exec.ExitError to return (a successful
+	// process isn't an error), so the caller asked for a contradiction.
+	return nil
+}
+
+// maxCapturedStderr bounds how much stderr content ExitError keeps in its
+// Stderr field, matching the cap os/exec's Output method applies to the
+// stderr it captures for a failed command (via its internal
+// prefixSuffixSaver, which keeps a 32 KiB prefix and a 32 KiB suffix), so a
+// mock fed unbounded stderr behaves the same way production code driving a
+// real subprocess would.
+const maxCapturedStderr = 64 << 10 // 64 KiB
+
+// capStderr trims stderr to maxCapturedStderr bytes, keeping its first and
+// last halves with an "omitting N bytes" marker in between when it has to
+// cut anything, the same shape os/exec's prefixSuffixSaver produces.
+func capStderr(stderr []byte) []byte {
+	if len(stderr) <= maxCapturedStderr {
+		return stderr
+	}
+	half := maxCapturedStderr / 2
+	omitted := len(stderr) - maxCapturedStderr
+	capped := make([]byte, 0, maxCapturedStderr+64)
+	capped = append(capped, stderr[:half]...)
+	capped = append(capped, []byte(fmt.Sprintf("\n... omitting %d bytes ...\n", omitted))...)
+	capped = append(capped, stderr[len(stderr)-half:]...)
+	return capped
+}
+
+// PermissionError builds an error with the same shape exec.Cmd.Start
+// produces when path exists but isn't executable: an *fs.PathError
+// wrapping fs.ErrPermission, so errors.Is(err, fs.ErrPermission) and a
+// type assertion to *fs.PathError succeed in tests exactly as they would
+// in production.
+func PermissionError(path string) error {
+	return &fs.PathError{Op: "fork/exec", Path: path, Err: fs.ErrPermission}
+}
@@ -0,0 +1,65 @@
+package mockexec
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os/exec"
+	"testing"
+)
+
+func Test_ExitError(t *testing.T) {
+	err := ExitError(17, []byte("disk full\n"))
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("ExitError() did not produce a *exec.ExitError: %v (%T)", err, err)
+	}
+	if exitErr.ExitCode() != 17 {
+		t.Errorf("ExitCode() = %d, want 17", exitErr.ExitCode())
+	}
+	if string(exitErr.Stderr) != "disk full\n" {
+		t.Errorf("Stderr = %q, want %q", exitErr.Stderr, "disk full\n")
+	}
+}
+
+func Test_ExitError_CapsOversizedStderr(t *testing.T) {
+	prefix := bytes.Repeat([]byte("a"), maxCapturedStderr/2)
+	suffix := bytes.Repeat([]byte("b"), maxCapturedStderr/2)
+	middle := bytes.Repeat([]byte("x"), 1024)
+	stderr := append(append(append([]byte{}, prefix...), middle...), suffix...)
+
+	err := ExitError(1, stderr)
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("ExitError() did not produce a *exec.ExitError: %v (%T)", err, err)
+	}
+	if len(exitErr.Stderr) >= len(stderr) {
+		t.Fatalf("Stderr len = %d, want it capped below the original %d bytes", len(exitErr.Stderr), len(stderr))
+	}
+	if !bytes.HasPrefix(exitErr.Stderr, prefix) {
+		t.Error("Stderr does not retain the original prefix")
+	}
+	if !bytes.HasSuffix(exitErr.Stderr, suffix) {
+		t.Error("Stderr does not retain the original suffix")
+	}
+	if !bytes.Contains(exitErr.Stderr, []byte("omitting")) {
+		t.Error("Stderr does not mention the omitted byte count")
+	}
+}
+
+func Test_PermissionError(t *testing.T) {
+	err := PermissionError("/usr/local/bin/deploy.sh")
+
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Fatalf("PermissionError() does not satisfy errors.Is(err, fs.ErrPermission): %v", err)
+	}
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("PermissionError() did not produce an *fs.PathError: %v (%T)", err, err)
+	}
+	if pathErr.Path != "/usr/local/bin/deploy.sh" {
+		t.Errorf("Path = %q, want %q", pathErr.Path, "/usr/local/bin/deploy.sh")
+	}
+}
@@ -0,0 +1,58 @@
+package mockexec
+
+import "context"
+
+// Exec is a struct-based alternative to the package-level
+// ShellCommanderFunc/ShellCommanderContextFunc/LookPathFunc var pattern
+// shown in this module's README: a service embeds an Exec field and calls
+// its Command/CommandContext/LookPath methods instead of depending on
+// package vars, so each instance - and each test - can be configured
+// independently. A shared package var works for a single package used by
+// one test at a time, but races when multiple packages (or parallel
+// subtests) need different fakes concurrently.
+//
+// The zero value is ready to use and behaves exactly like the real thing;
+// tests construct one with CommandFunc/CommandContextFunc/LookPathFunc set
+// to a mock.
+type Exec struct {
+	// CommandFunc, if set, backs Command. Defaults to NewExecShellCommander.
+	CommandFunc ShellCommanderFunc
+	// CommandContextFunc, if set, backs CommandContext. Defaults to
+	// NewExecShellCommanderContext.
+	CommandContextFunc ShellCommanderContextFunc
+	// LookPathFunc, if set, backs LookPath. Defaults to NewExecLookPath.
+	LookPathFunc LookPathFunc
+}
+
+// NewExec returns an Exec backed by the real, exec-backed implementations,
+// spelled out for callers that find &Exec{} less obvious.
+func NewExec() *Exec {
+	return &Exec{}
+}
+
+// Command runs name/args through CommandFunc, or NewExecShellCommander if
+// CommandFunc is unset.
+func (e *Exec) Command(name string, arg ...string) IShellCommand {
+	if e.CommandFunc != nil {
+		return e.CommandFunc(name, arg...)
+	}
+	return NewExecShellCommander(name, arg...)
+}
+
+// CommandContext runs name/args through CommandContextFunc, or
+// NewExecShellCommanderContext if CommandContextFunc is unset.
+func (e *Exec) CommandContext(ctx context.Context, name string, arg ...string) IShellCommand {
+	if e.CommandContextFunc != nil {
+		return e.CommandContextFunc(ctx, name, arg...)
+	}
+	return NewExecShellCommanderContext(ctx, name, arg...)
+}
+
+// LookPath runs file through LookPathFunc, or NewExecLookPath if
+// LookPathFunc is unset.
+func (e *Exec) LookPath(file string) (string, error) {
+	if e.LookPathFunc != nil {
+		return e.LookPathFunc(file)
+	}
+	return NewExecLookPath(file)
+}
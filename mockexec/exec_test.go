@@ -0,0 +1,66 @@
+package mockexec
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func Test_Exec_ZeroValueRunsRealCommand(t *testing.T) {
+	e := &Exec{}
+
+	out, err := e.Command("echo", "hello").Output()
+	if err != nil || string(out) != "hello\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "hello\n")
+	}
+}
+
+func Test_Exec_CommandFuncOverride(t *testing.T) {
+	e := &Exec{CommandFunc: NewMockShellCommanderNotFound()}
+
+	_, err := e.Command("nope").Output()
+	if err == nil {
+		t.Fatal("Output() = nil error, want the fake not-found error")
+	}
+}
+
+func Test_Exec_CommandContextFuncOverride(t *testing.T) {
+	wantErr := errors.New("context command failed")
+	e := &Exec{
+		CommandContextFunc: func(ctx context.Context, name string, arg ...string) IShellCommand {
+			return &MockShellCommand{Err: wantErr}
+		},
+	}
+
+	_, err := e.CommandContext(context.Background(), "git", "status").Output()
+	if err != wantErr {
+		t.Fatalf("Output() err = %v, want %v", err, wantErr)
+	}
+}
+
+func Test_Exec_LookPathFuncOverride(t *testing.T) {
+	e := &Exec{
+		LookPathFunc: func(file string) (string, error) {
+			return "/usr/bin/" + file, nil
+		},
+	}
+
+	path, err := e.LookPath("git")
+	if err != nil || path != "/usr/bin/git" {
+		t.Fatalf("LookPath() = (%q, %v), want (%q, nil)", path, err, "/usr/bin/git")
+	}
+}
+
+// Two independent Exec instances don't share state - the library's answer
+// to the global-var pattern not scaling to concurrent, multi-package use.
+func Test_Exec_InstancesAreIndependent(t *testing.T) {
+	a := &Exec{CommandFunc: NewMockShellCommanderNotFound()}
+	b := &Exec{}
+
+	if _, err := b.Command("echo", "hi").Output(); err != nil {
+		t.Fatalf("b.Command() should be unaffected by a's override, got err %v", err)
+	}
+	if _, err := a.Command("anything").Output(); err == nil {
+		t.Fatal("a.Command() should still use its own override")
+	}
+}
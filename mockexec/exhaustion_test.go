@@ -0,0 +1,55 @@
+package mockexec
+
+import "testing"
+
+func Test_ReturnOnce_DefaultExhaustionRepeatsTerminal(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "fetch").ReturnOnce("", nil).Return("up to date", nil)
+
+	mc.Commander("git", "fetch").Output()
+	out, err := mc.Commander("git", "fetch").Output()
+	if err != nil || string(out) != "up to date" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "up to date")
+	}
+}
+
+func Test_ReturnOnce_ExhaustRepeatLast(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "fetch").
+		ReturnOnce("first", nil).
+		ReturnOnce("second", nil).
+		OnExhausted(ExhaustRepeatLast)
+
+	mc.Commander("git", "fetch").Output()
+	mc.Commander("git", "fetch").Output()
+	out, err := mc.Commander("git", "fetch").Output()
+	if err != nil || string(out) != "second" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "second")
+	}
+}
+
+func Test_ReturnOnce_ExhaustFailPanics(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "fetch").ReturnOnce("ok", nil).OnExhausted(ExhaustFail)
+
+	mc.Commander("git", "fetch").Output()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Commander to panic once the queue was exhausted")
+		}
+	}()
+	mc.Commander("git", "fetch")
+}
+
+func Test_ReturnOnce_ExhaustFallbackDefault(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "fetch").ReturnOnce("ok", nil).OnExhausted(ExhaustFallbackDefault)
+	mc.DefaultReturn("fallback", nil)
+
+	mc.Commander("git", "fetch").Output()
+	out, err := mc.Commander("git", "fetch").Output()
+	if err != nil || string(out) != "fallback" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "fallback")
+	}
+}
@@ -0,0 +1,663 @@
+package mockexec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type mockResponse struct {
+	output  string
+	err     error
+	delay   time.Duration
+	corrupt func(string) string
+}
+
+// Response is a scripted output/error pair, used as the success response
+// in Flaky.
+type Response struct {
+	Output string
+	Err    error
+}
+
+// InvocationResponseFunc computes a response from the invocation itself -
+// the command name, its arguments, the working directory set via SetDir,
+// and the environment set via SetEnv - so a response can echo back
+// arguments, read a --file flag, or vary by working directory.
+type InvocationResponseFunc func(name string, args []string, dir string, env []string) (stdout []byte, stderr []byte, err error)
+
+// Expectation describes one expected invocation registered via
+// MockCommander.ExpectCommand. Use Return to configure what it produces, or
+// ReturnOnce to queue distinct responses for successive calls.
+type Expectation struct {
+	name string
+	args []ArgMatcher
+
+	// argsMatcher, if ExpectCommand was called with a single ArgsMatcher
+	// instead of per-position args, matches the whole invocation's
+	// argument list at once instead of positionally. args is left empty
+	// when this is set.
+	argsMatcher ArgsMatcher
+
+	// fn, if set via ReturnFunc, takes priority over queue/terminal and
+	// computes the response from the invocation.
+	fn InvocationResponseFunc
+
+	// queue holds one-shot responses set up via ReturnOnce, consumed in order.
+	queue []mockResponse
+	// terminal is what's returned once the queue is exhausted; it's what
+	// Return configures, and defaults to a nil-error empty response.
+	terminal mockResponse
+	// lastIsTerminal tracks whether Return or ReturnOnce configured the most
+	// recently set response, so After knows where to attach its delay.
+	lastIsTerminal bool
+
+	// queueUsed is true once ReturnOnce has been called at least once, so
+	// exhaustion handles "queue drained" differently from "no queue was
+	// ever configured" (the latter just falls through to terminal, Return's
+	// original behavior).
+	queueUsed bool
+	// lastDequeued is the most recently dequeued ReturnOnce response, used
+	// by ExhaustRepeatLast.
+	lastDequeued *mockResponse
+	// exhaustion configures what happens once queueUsed is true and queue
+	// has run dry; see ExhaustionPolicy.
+	exhaustion ExhaustionPolicy
+
+	// wantDir, if set via WithDir, is checked against each matched call's
+	// SetDir argument by Verify, since the directory isn't known until
+	// after Commander returns (the caller calls SetDir on the result).
+	wantDir *string
+	// wantEnv, if set via WithEnv, lists KEY=value entries that must all
+	// appear in each matched call's SetEnv argument.
+	wantEnv []string
+	// wantEnvFunc, if set via WithEnvMatching, is called with each matched
+	// call's SetEnv argument instead of the simpler WithEnv subset check.
+	wantEnvFunc func([]string) bool
+	// wantStdinJSON, if set via WithStdinJSON, is the expected stdin
+	// content (captured via SetStdin or StdinPipe), compared to each
+	// matched call's CapturedStdin as JSON rather than as literal bytes,
+	// so formatting differences (whitespace, key order) don't fail the test.
+	wantStdinJSON *string
+	// wantStdinMatch, if set via WithStdinMatching, is checked against each
+	// matched call's CapturedStdin by Verify, which reports its error
+	// (expected to describe the mismatch) as a test failure.
+	wantStdinMatch StdinMatcher
+	// cmds records every MockShellCommand this expectation has handed out,
+	// so Verify can inspect what was actually done with each (e.g. its dir)
+	// once the test has finished driving them.
+	cmds []*MockShellCommand
+
+	// wantCalls is the cardinality configured via Times/AtLeast/AtMost/Never,
+	// checked by Verify. The zero value means "at least once", matching the
+	// library's original default.
+	wantCalls callCardinality
+
+	// after, if set via InOrder, is the expectation that must be matched at
+	// least once before this one is eligible to match.
+	after *Expectation
+
+	calls int
+}
+
+// callCardinality describes how many times an expectation must be matched.
+// The zero value requires at least one call.
+type callCardinality struct {
+	exact *int
+	min   *int
+	max   *int
+}
+
+// check reports whether calls violates the configured cardinality, and if
+// so, a description of what was wanted for use in a failure message.
+func (c callCardinality) check(calls int) (violated bool, want string) {
+	switch {
+	case c.exact != nil:
+		if calls != *c.exact {
+			return true, fmt.Sprintf("exactly %d", *c.exact)
+		}
+	case c.min != nil || c.max != nil:
+		if c.min != nil && calls < *c.min {
+			return true, fmt.Sprintf("at least %d", *c.min)
+		}
+		if c.max != nil && calls > *c.max {
+			return true, fmt.Sprintf("at most %d", *c.max)
+		}
+	default:
+		if calls == 0 {
+			return true, "at least 1"
+		}
+	}
+	return false, ""
+}
+
+// Return sets the response returned once any ReturnOnce-queued responses
+// are exhausted (or immediately, if ReturnOnce was never called).
+func (e *Expectation) Return(output string, err error) *Expectation {
+	e.terminal = mockResponse{output: output, err: err}
+	e.lastIsTerminal = true
+	return e
+}
+
+// ReturnOnce queues a response to be returned for the next call only. Queued
+// responses are consumed in the order they were added; once the queue is
+// empty, subsequent calls are handled per OnExhausted (by default, they
+// fall back to the response configured via Return).
+func (e *Expectation) ReturnOnce(output string, err error) *Expectation {
+	e.queue = append(e.queue, mockResponse{output: output, err: err})
+	e.queueUsed = true
+	e.lastIsTerminal = false
+	return e
+}
+
+// ExhaustionPolicy controls what happens once an Expectation's ReturnOnce
+// queue has been drained and it's matched again.
+type ExhaustionPolicy int
+
+const (
+	// ExhaustRepeatTerminal, the default, returns the response configured
+	// via Return (or the zero Response, if Return was never called) for
+	// every call past the end of the queue.
+	ExhaustRepeatTerminal ExhaustionPolicy = iota
+	// ExhaustRepeatLast keeps returning whatever the last queued response
+	// was, ignoring any separately configured Return.
+	ExhaustRepeatLast
+	// ExhaustFail panics, surfacing as a test failure, instead of producing
+	// a response once the queue is drained.
+	ExhaustFail
+	// ExhaustFallbackDefault routes calls past the end of the queue to the
+	// MockCommander's SetDefault/DefaultReturn handler instead of this
+	// expectation.
+	ExhaustFallbackDefault
+)
+
+// OnExhausted configures what happens once this expectation's ReturnOnce
+// queue runs dry; see ExhaustionPolicy. It has no effect on an expectation
+// that never uses ReturnOnce.
+func (e *Expectation) OnExhausted(policy ExhaustionPolicy) *Expectation {
+	e.exhaustion = policy
+	return e
+}
+
+// After makes the most recently configured response (via Return or
+// ReturnOnce) take delay to produce, so tests can exercise timeout budgets
+// or "slow command" warnings without real sleeps scattered through test code.
+func (e *Expectation) After(delay time.Duration) *Expectation {
+	if e.lastIsTerminal {
+		e.terminal.delay = delay
+	} else if len(e.queue) > 0 {
+		e.queue[len(e.queue)-1].delay = delay
+	}
+	return e
+}
+
+// WithDir requires that every matched invocation's SetDir was called with
+// dir, checked by Verify once the test is done driving the mock (the
+// directory isn't known at match time, since SetDir is called on the
+// command Commander hands back, not passed to Commander itself).
+func (e *Expectation) WithDir(dir string) *Expectation {
+	e.wantDir = &dir
+	return e
+}
+
+// WithEnv requires that every matched invocation's SetEnv included each of
+// the given KEY=value entries (order and extra entries don't matter),
+// checked by Verify. Like WithDir, the environment isn't known at match
+// time since SetEnv is called on the command Commander hands back.
+func (e *Expectation) WithEnv(kv ...string) *Expectation {
+	e.wantEnv = kv
+	return e
+}
+
+// WithEnvMatching requires that every matched invocation's SetEnv argument
+// satisfies match, for checks WithEnv's subset matching can't express.
+func (e *Expectation) WithEnvMatching(match func([]string) bool) *Expectation {
+	e.wantEnvFunc = match
+	return e
+}
+
+// WithStdinJSON requires that every matched invocation's captured stdin
+// (whichever of SetStdin or StdinPipe the caller used) is JSON-equal to
+// expected - decoded and compared structurally, not byte-for-byte - checked
+// by Verify. Useful for commands like `kubectl apply -f -` that take a
+// manifest on stdin.
+func (e *Expectation) WithStdinJSON(expected string) *Expectation {
+	e.wantStdinJSON = &expected
+	return e
+}
+
+// StdinMatcher checks a matched invocation's captured stdin, returning nil
+// if it matches or an error describing the mismatch (suitable for use
+// directly as a test failure message) otherwise. Use StdinJSONEq,
+// StdinYAMLEq, or StdinContains, or write a custom func for checks those
+// can't express.
+type StdinMatcher func(got string) error
+
+// StdinJSONEq returns a StdinMatcher requiring stdin to be JSON-equal to
+// want - decoded and compared structurally, not byte-for-byte - the same
+// comparison WithStdinJSON performs, available here for composing with
+// WithStdinMatching.
+func StdinJSONEq(want string) StdinMatcher {
+	return func(got string) error {
+		equal, err := jsonEqual(got, want)
+		if err != nil {
+			return err
+		}
+		if !equal {
+			return fmt.Errorf("got stdin %s, want JSON-equal to %s", got, want)
+		}
+		return nil
+	}
+}
+
+// StdinYAMLEq returns a StdinMatcher requiring stdin to be YAML-equal to
+// want - decoded and compared structurally, not byte-for-byte - for
+// commands like `kubectl apply -f -` that take a rendered manifest on
+// stdin.
+func StdinYAMLEq(want string) StdinMatcher {
+	return func(got string) error {
+		equal, err := yamlEqual(got, want)
+		if err != nil {
+			return err
+		}
+		if !equal {
+			return fmt.Errorf("got stdin %s, want YAML-equal to %s", got, want)
+		}
+		return nil
+	}
+}
+
+// StdinContains returns a StdinMatcher requiring stdin to contain substr as
+// a literal substring.
+func StdinContains(substr string) StdinMatcher {
+	return func(got string) error {
+		if !strings.Contains(got, substr) {
+			return fmt.Errorf("got stdin %s, want it to contain %q", got, substr)
+		}
+		return nil
+	}
+}
+
+// WithStdinMatching requires that every matched invocation's captured stdin
+// (whichever of SetStdin or StdinPipe the caller used) satisfies match,
+// checked by Verify. Prefer this over WithStdinJSON for non-JSON payloads
+// or checks StdinJSONEq/StdinYAMLEq/StdinContains can't express - match can
+// be a custom func reporting its own diff.
+func (e *Expectation) WithStdinMatching(match StdinMatcher) *Expectation {
+	e.wantStdinMatch = match
+	return e
+}
+
+// ReturnFunc configures the response to be computed from each matching
+// invocation via fn, overriding any responses configured via Return or
+// ReturnOnce.
+func (e *Expectation) ReturnFunc(fn InvocationResponseFunc) *Expectation {
+	e.fn = fn
+	return e
+}
+
+// Flaky scripts the classic "fails n times, then succeeds" sequence: the
+// first n matching calls return failErr, and every call after that returns
+// success. Combine with Attempts to assert retry/backoff logic made exactly
+// the expected number of attempts.
+func (e *Expectation) Flaky(n int, failErr error, success Response) *Expectation {
+	for i := 0; i < n; i++ {
+		e.ReturnOnce("", failErr)
+	}
+	return e.Return(success.Output, success.Err)
+}
+
+// Attempts returns how many times this expectation has been matched so far.
+func (e *Expectation) Attempts() int {
+	return e.calls
+}
+
+// Times requires the expectation to be matched exactly n times, overriding
+// the default of "at least once".
+func (e *Expectation) Times(n int) *Expectation {
+	e.wantCalls.exact = &n
+	return e
+}
+
+// AtLeast requires the expectation to be matched at least n times.
+func (e *Expectation) AtLeast(n int) *Expectation {
+	e.wantCalls.min = &n
+	return e
+}
+
+// AtLeastOnce is AtLeast(1), the library's implicit default, spelled out
+// for tests that want to combine it with AtMost.
+func (e *Expectation) AtLeastOnce() *Expectation {
+	return e.AtLeast(1)
+}
+
+// AtMost requires the expectation to be matched at most n times.
+func (e *Expectation) AtMost(n int) *Expectation {
+	e.wantCalls.max = &n
+	return e
+}
+
+// Never requires the expectation to never be matched - Times(0) spelled out
+// for tests asserting a command is NOT run under some condition.
+func (e *Expectation) Never() *Expectation {
+	return e.Times(0)
+}
+
+// queueOutcome is what nextResponse decided should happen for one call:
+// either a response to return, or a signal to fail the test or fall back
+// to the MockCommander's default handler.
+type queueOutcome struct {
+	resp              mockResponse
+	fail              bool
+	fallbackToDefault bool
+}
+
+func (e *Expectation) nextResponse() queueOutcome {
+	if len(e.queue) > 0 {
+		r := e.queue[0]
+		e.queue = e.queue[1:]
+		e.lastDequeued = &r
+		return queueOutcome{resp: r}
+	}
+	if !e.queueUsed {
+		return queueOutcome{resp: e.terminal}
+	}
+	switch e.exhaustion {
+	case ExhaustFail:
+		return queueOutcome{fail: true}
+	case ExhaustFallbackDefault:
+		return queueOutcome{fallbackToDefault: true}
+	case ExhaustRepeatLast:
+		if e.lastDequeued != nil {
+			return queueOutcome{resp: *e.lastDequeued}
+		}
+		return queueOutcome{resp: e.terminal}
+	default: // ExhaustRepeatTerminal
+		return queueOutcome{resp: e.terminal}
+	}
+}
+
+func (e *Expectation) matches(name string, args []string, normalizeNames bool) bool {
+	wantName, gotName := e.name, name
+	if normalizeNames {
+		wantName, gotName = normalizeCommandName(wantName), normalizeCommandName(gotName)
+	}
+	if wantName != gotName {
+		return false
+	}
+	if e.argsMatcher != nil {
+		return e.argsMatcher.MatchArgs(args)
+	}
+	if len(e.args) != len(args) {
+		return false
+	}
+	for i, m := range e.args {
+		if !m.Match(args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MockCommander is a gomock-style commander: register expected invocations
+// with ExpectCommand(...).Return(...), use Commander as the ShellCommanderFunc,
+// then call Verify at the end of the test to fail if any expectation was unmet.
+type MockCommander struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+
+	// Clock, if set, is used to realize Expectation.After delays instead of
+	// time.Sleep, so a fake clock (e.g. benbjohnson/clock's Mock) can advance
+	// virtual time and keep delay-exercising tests fast.
+	Clock Clock
+
+	// unmatched, if set via SetDefault, handles invocations that match no
+	// expectation instead of the default behavior of panicking. Use
+	// DefaultReturn for "return this canned output", or StrictCommander
+	// for "fail the test".
+	unmatched func(name string, args []string) IShellCommand
+
+	// NormalizeWindowsNames, if set, makes ExpectCommand's name match
+	// invocations regardless of a ".exe" suffix or backslash-vs-forward-
+	// slash path separators, so expectations written against "git" still
+	// match "git.exe" or a full Windows path to it, without per-platform
+	// test code.
+	NormalizeWindowsNames bool
+}
+
+// SetDefault installs handler for invocations that match no expectation,
+// replacing the default behavior of panicking.
+func (mc *MockCommander) SetDefault(handler func(name string, args []string) IShellCommand) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.unmatched = handler
+}
+
+// DefaultReturn is a SetDefault convenience for the common case: every
+// unmatched invocation returns the same canned output/error instead of
+// failing.
+func (mc *MockCommander) DefaultReturn(output string, err error) {
+	mc.SetDefault(func(name string, args []string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte(output), Err: err}
+	})
+}
+
+// NewMockCommander creates an empty MockCommander.
+func NewMockCommander() *MockCommander {
+	return &MockCommander{}
+}
+
+// NewMockCommanderT creates an empty MockCommander scoped to t: t.Cleanup
+// runs Verify(t) automatically once t finishes, so a caller doesn't have
+// to remember the trailing mc.Verify(t) call. Call it again inside each
+// t.Run subtest that needs its own expectations - each call returns a
+// fresh MockCommander, so a subtest's expectations and call history can't
+// leak into, or be left over from, a sibling subtest sharing the same
+// parent setup.
+func NewMockCommanderT(t *testing.T) *MockCommander {
+	mc := NewMockCommander()
+	t.Cleanup(func() { mc.Verify(t) })
+	return mc
+}
+
+// ExpectCommand registers an expectation that name is run with args that
+// match, positionally, the given args. Each arg may be a plain string
+// (exact match) or an ArgMatcher such as Any(), Regexp(...), Glob(...), or
+// Prefix(...).
+func (mc *MockCommander) ExpectCommand(name string, args ...interface{}) *Expectation {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if len(args) == 1 {
+		if am, ok := args[0].(ArgsMatcher); ok {
+			e := &Expectation{name: name, argsMatcher: am}
+			mc.expectations = append(mc.expectations, e)
+			return e
+		}
+	}
+	matchers := make([]ArgMatcher, len(args))
+	for i, a := range args {
+		matchers[i] = toArgMatcher(a)
+	}
+	e := &Expectation{name: name, args: matchers}
+	mc.expectations = append(mc.expectations, e)
+	return e
+}
+
+// Commander is a ShellCommanderFunc that resolves each call against the
+// registered expectations, in registration order, using the first one whose
+// name and args match and whose InOrder predecessor (if any) has already
+// been matched. An expectation keeps matching past its Times/AtMost count;
+// Verify reports the excess calls. Unexpected calls panic,
+// since there is no *testing.T available at commander-call time, unless
+// SetDefault/DefaultReturn configured a fallback.
+func (mc *MockCommander) Commander(name string, args ...string) IShellCommand {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	clock := mc.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	var blockedByOrder *Expectation
+	for _, e := range mc.expectations {
+		if e.matches(name, args, mc.NormalizeWindowsNames) {
+			if e.after != nil && e.after.calls == 0 {
+				if blockedByOrder == nil {
+					blockedByOrder = e
+				}
+				continue
+			}
+			e.calls++
+			if e.fn != nil {
+				fn := e.fn
+				cmd := &MockShellCommand{}
+				cmd.OutputterFunc = func() ([]byte, error) {
+					stdout, _, err := fn(name, args, cmd.dir, cmd.env)
+					return stdout, err
+				}
+				cmd.CombinedOutputterFunc = func() ([]byte, error) {
+					stdout, stderr, err := fn(name, args, cmd.dir, cmd.env)
+					return append(append([]byte{}, stdout...), stderr...), err
+				}
+				e.cmds = append(e.cmds, cmd)
+				return cmd
+			}
+			outcome := e.nextResponse()
+			if outcome.fail {
+				panic(fmt.Sprintf("mockexec: expectation %v %v: ReturnOnce queue exhausted (ExhaustFail)", e.name, e.args))
+			}
+			if outcome.fallbackToDefault {
+				if mc.unmatched != nil {
+					return mc.unmatched(name, args)
+				}
+				panic(fmt.Sprintf("mockexec: expectation %v %v: ReturnOnce queue exhausted and no default handler registered (ExhaustFallbackDefault)", e.name, e.args))
+			}
+			resp := outcome.resp
+			cmd := &MockShellCommand{
+				OutputterFunc: func() ([]byte, error) {
+					if resp.delay > 0 {
+						clock.Sleep(resp.delay)
+					}
+					output := resp.output
+					if resp.corrupt != nil {
+						output = resp.corrupt(output)
+					}
+					return []byte(output), resp.err
+				},
+			}
+			e.cmds = append(e.cmds, cmd)
+			return cmd
+		}
+	}
+	if mc.unmatched != nil {
+		return mc.unmatched(name, args)
+	}
+	if blockedByOrder != nil {
+		panic(fmt.Sprintf("mockexec: command %s ran out of order; expected %v %v first (see InOrder)",
+			QuoteCommandLine(name, args), blockedByOrder.after.name, blockedByOrder.after.args))
+	}
+	panic(fmt.Sprintf("mockexec: unexpected command %s", QuoteCommandLine(name, args)))
+}
+
+// InOrder chains expectations so each one may only be matched once the
+// expectation before it has been matched at least once, mirroring gomock's
+// ordering semantics: relative order between the given expectations is
+// enforced, while calls to any other expectation remain unconstrained.
+func InOrder(exps ...*Expectation) {
+	for i := 1; i < len(exps); i++ {
+		exps[i].after = exps[i-1]
+	}
+}
+
+// Verify fails t if any registered expectation's call count violates its
+// cardinality (at least once, unless Times/AtLeast/AtMost/Never set another),
+// or if a matched call was started without Wait or broke the expectation's
+// WithDir, WithEnv, WithEnvMatching, WithStdinJSON or WithStdinMatching
+// requirement.
+func (mc *MockCommander) Verify(t *testing.T) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for _, e := range mc.expectations {
+		if violated, want := e.wantCalls.check(e.calls); violated {
+			t.Errorf("mockexec: command %v %v called %d times, want %s", e.name, e.args, e.calls, want)
+			continue
+		}
+		for i, cmd := range e.cmds {
+			if cmd.StartedWithoutWait() {
+				t.Errorf("mockexec: command %v %v (call %d) called Start but never Wait - looks like a leaked process", e.name, e.args, i+1)
+			}
+			if e.wantDir != nil {
+				if got := cmd.Dir(); got != *e.wantDir {
+					t.Errorf("mockexec: command %v %v (call %d) ran in dir %q, want %q", e.name, e.args, i+1, got, *e.wantDir)
+				}
+			}
+			if missing := missingEnv(cmd.Environ(), e.wantEnv); len(missing) > 0 {
+				t.Errorf("mockexec: command %v %v (call %d) ran with env %v, missing %v", e.name, e.args, i+1, cmd.Environ(), missing)
+			}
+			if e.wantEnvFunc != nil && !e.wantEnvFunc(cmd.Environ()) {
+				t.Errorf("mockexec: command %v %v (call %d) ran with env %v, which didn't satisfy WithEnvMatching", e.name, e.args, i+1, cmd.Environ())
+			}
+			if e.wantStdinJSON != nil {
+				if equal, err := jsonEqual(cmd.CapturedStdin(), *e.wantStdinJSON); err != nil {
+					t.Errorf("mockexec: command %v %v (call %d): %v", e.name, e.args, i+1, err)
+				} else if !equal {
+					t.Errorf("mockexec: command %v %v (call %d) got stdin %s, want %s", e.name, e.args, i+1, cmd.CapturedStdin(), *e.wantStdinJSON)
+				}
+			}
+			if e.wantStdinMatch != nil {
+				if err := e.wantStdinMatch(cmd.CapturedStdin()); err != nil {
+					t.Errorf("mockexec: command %v %v (call %d): %v", e.name, e.args, i+1, err)
+				}
+			}
+		}
+	}
+}
+
+// jsonEqual reports whether got and want decode to structurally equal JSON
+// values, or an error if either fails to decode.
+func jsonEqual(got, want string) (bool, error) {
+	var gotVal, wantVal interface{}
+	if err := json.Unmarshal([]byte(got), &gotVal); err != nil {
+		return false, fmt.Errorf("captured stdin is not valid JSON: %w", err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantVal); err != nil {
+		return false, fmt.Errorf("WithStdinJSON argument is not valid JSON: %w", err)
+	}
+	return reflect.DeepEqual(gotVal, wantVal), nil
+}
+
+// yamlEqual reports whether got and want decode to structurally equal YAML
+// values, or an error if either fails to decode.
+func yamlEqual(got, want string) (bool, error) {
+	var gotVal, wantVal interface{}
+	if err := yaml.Unmarshal([]byte(got), &gotVal); err != nil {
+		return false, fmt.Errorf("captured stdin is not valid YAML: %w", err)
+	}
+	if err := yaml.Unmarshal([]byte(want), &wantVal); err != nil {
+		return false, fmt.Errorf("StdinYAMLEq argument is not valid YAML: %w", err)
+	}
+	return reflect.DeepEqual(gotVal, wantVal), nil
+}
+
+// missingEnv returns the entries of want not present in got.
+func missingEnv(got, want []string) []string {
+	present := make(map[string]bool, len(got))
+	for _, kv := range got {
+		present[kv] = true
+	}
+	var missing []string
+	for _, kv := range want {
+		if !present[kv] {
+			missing = append(missing, kv)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,23 @@
+package mockexec
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_MockCommander_ReturnOnceSequence(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("flaky").
+		ReturnOnce("", errors.New("transient failure")).
+		Return("ok", nil)
+
+	if _, err := mc.Commander("flaky").Output(); err == nil {
+		t.Fatal("expected first call to fail")
+	}
+	if out, err := mc.Commander("flaky").Output(); err != nil || string(out) != "ok" {
+		t.Errorf("second call = (%q, %v), want (%q, nil)", out, err, "ok")
+	}
+	if out, err := mc.Commander("flaky").Output(); err != nil || string(out) != "ok" {
+		t.Errorf("third call = (%q, %v), want terminal response to repeat", out, err)
+	}
+}
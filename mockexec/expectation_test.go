@@ -0,0 +1,106 @@
+package mockexec
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_MockCommander_ExpectCommandAndVerify(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "rev-parse", "--abbrev-ref", "HEAD").Return("main", nil)
+
+	cmd := mc.Commander("git", "rev-parse", "--abbrev-ref", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+	if string(output) != "main" {
+		t.Errorf("Output() = %q, want %q", output, "main")
+	}
+
+	mc.Verify(t)
+}
+
+func Test_MockCommander_VerifyFailsWhenUncalled(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "status")
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("expected Verify to fail when an expectation is never called")
+	}
+}
+
+func Test_MockCommander_UnexpectedCommand_PanicsWithQuotedLine(t *testing.T) {
+	mc := NewMockCommander()
+
+	defer func() {
+		r := recover()
+		msg, ok := r.(string)
+		if !ok {
+			t.Fatalf("expected a string panic, got %v (%T)", r, r)
+		}
+		want := `git push --force "origin main"`
+		if !strings.Contains(msg, want) {
+			t.Errorf("panic message %q does not contain the copy-pasteable line %q", msg, want)
+		}
+	}()
+
+	mc.Commander("git", "push", "--force", "origin main")
+}
+
+func Test_MockCommander_VerifyFailsOnStartWithoutWait(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("sleep", "5")
+
+	cmd := mc.Commander("sleep", "5")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	// Wait is never called - simulates production code that leaks the process.
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("expected Verify to fail when Start was called without a matching Wait")
+	}
+}
+
+func Test_MockCommander_VerifyPassesOnStartThenWait(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("sleep", "5")
+
+	cmd := mc.Commander("sleep", "5")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("Wait() failed: %v", err)
+	}
+
+	mc.Verify(t)
+}
+
+func Test_NewMockCommanderT_VerifiesAndDoesNotLeakBetweenSubtests(t *testing.T) {
+	t.Run("case one", func(t *testing.T) {
+		mc := NewMockCommanderT(t)
+		mc.ExpectCommand("git", "status").Return("clean", nil)
+
+		if _, err := mc.Commander("git", "status").Output(); err != nil {
+			t.Fatalf("Output() failed: %v", err)
+		}
+	})
+
+	t.Run("case two", func(t *testing.T) {
+		// A fresh NewMockCommanderT call means "git status" isn't expected
+		// here - if case one's expectation leaked in, this mc would already
+		// think it had been called.
+		mc := NewMockCommanderT(t)
+		mc.ExpectCommand("git", "log").Return("commit abc", nil)
+
+		if _, err := mc.Commander("git", "log").Output(); err != nil {
+			t.Fatalf("Output() failed: %v", err)
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package mockexec
+
+import (
+	"os"
+	"testing"
+)
+
+func Test_MockShellCommand_ExtraFilesCaptured(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() failed: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	sc := &MockShellCommand{}
+	sc.SetExtraFiles([]*os.File{r, w})
+
+	files := sc.ExtraFiles()
+	if len(files) != 2 || files[0] != r || files[1] != w {
+		t.Errorf("ExtraFiles() = %v, want [%v %v]", files, r, w)
+	}
+
+	names := sc.ExtraFileNames()
+	if len(names) != 2 || names[0] != r.Name() || names[1] != w.Name() {
+		t.Errorf("ExtraFileNames() = %v, want [%q %q]", names, r.Name(), w.Name())
+	}
+}
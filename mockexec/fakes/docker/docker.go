@@ -0,0 +1,142 @@
+// Package docker is a ready-made fake of the common docker CLI subcommands
+// (ps, inspect, run, build), backed by configurable in-memory container and
+// image state, so CI pipeline tools that shell out to docker can be unit
+// tested without hand-writing JSON fixtures for every scenario.
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"mock_exec/mockexec"
+)
+
+// Container is a fake container's state, rendered as `docker ps`/`docker
+// inspect` JSON would render a real one.
+type Container struct {
+	ID     string `json:"Id"`
+	Name   string `json:"Names"`
+	Image  string `json:"Image"`
+	Status string `json:"Status"`
+}
+
+// Image is a fake image's state, as listed by `docker images` or produced
+// by `docker build`.
+type Image struct {
+	ID   string `json:"Id"`
+	Repo string `json:"Repository"`
+	Tag  string `json:"Tag"`
+}
+
+// Daemon is an in-memory stand-in for a docker daemon. Construct it with
+// NewDaemon, seed it with AddContainer/AddImage, then use its Commander as
+// a package's shellCommander in tests.
+type Daemon struct {
+	mu         sync.Mutex
+	containers []Container
+	images     []Image
+	nextID     int
+}
+
+// NewDaemon creates an empty Daemon.
+func NewDaemon() *Daemon {
+	return &Daemon{}
+}
+
+// AddContainer seeds the daemon with an existing container.
+func (d *Daemon) AddContainer(c Container) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.containers = append(d.containers, c)
+}
+
+// AddImage seeds the daemon with an existing image.
+func (d *Daemon) AddImage(i Image) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.images = append(d.images, i)
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (d *Daemon) Commander(name string, args ...string) mockexec.IShellCommand {
+	if name != "docker" {
+		return &mockexec.MockShellCommand{Err: mockexec.NotFoundError(name)}
+	}
+	if len(args) == 0 {
+		return errCommand(fmt.Errorf("docker: missing subcommand"))
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch args[0] {
+	case "ps":
+		return d.ps()
+	case "inspect":
+		return d.inspect(args[1:])
+	case "run":
+		return d.run(args[1:])
+	case "build":
+		return d.build(args[1:])
+	default:
+		return errCommand(fmt.Errorf("docker: fake does not implement subcommand %q", args[0]))
+	}
+}
+
+func (d *Daemon) ps() mockexec.IShellCommand {
+	return jsonCommand(d.containers)
+}
+
+func (d *Daemon) inspect(args []string) mockexec.IShellCommand {
+	if len(args) == 0 {
+		return errCommand(fmt.Errorf("docker: inspect requires a container ID or name"))
+	}
+	target := args[len(args)-1]
+	for _, c := range d.containers {
+		if c.ID == target || c.Name == target {
+			return jsonCommand([]Container{c})
+		}
+	}
+	return errCommand(fmt.Errorf("docker: Error: No such object: %s", target))
+}
+
+func (d *Daemon) run(args []string) mockexec.IShellCommand {
+	image := ""
+	if len(args) > 0 {
+		image = args[len(args)-1]
+	}
+	d.nextID++
+	id := fmt.Sprintf("fakecontainer%d", d.nextID)
+	d.containers = append(d.containers, Container{ID: id, Image: image, Status: "Up"})
+	return okCommand(id + "\n")
+}
+
+func (d *Daemon) build(args []string) mockexec.IShellCommand {
+	tag := ""
+	for i, a := range args {
+		if a == "-t" && i+1 < len(args) {
+			tag = args[i+1]
+		}
+	}
+	d.nextID++
+	id := fmt.Sprintf("fakeimage%d", d.nextID)
+	d.images = append(d.images, Image{ID: id, Repo: tag})
+	return okCommand(fmt.Sprintf("Successfully built %s\nSuccessfully tagged %s\n", id, tag))
+}
+
+func jsonCommand(v interface{}) mockexec.IShellCommand {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return errCommand(err)
+	}
+	return okCommand(string(out) + "\n")
+}
+
+func okCommand(output string) mockexec.IShellCommand {
+	return &mockexec.MockShellCommand{Stdout: []byte(output)}
+}
+
+func errCommand(err error) mockexec.IShellCommand {
+	return &mockexec.MockShellCommand{Err: err}
+}
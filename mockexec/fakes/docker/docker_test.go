@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_Daemon_PsAndInspect(t *testing.T) {
+	d := NewDaemon()
+	d.AddContainer(Container{ID: "abc123", Name: "web", Image: "nginx", Status: "Up 2 hours"})
+
+	out, err := d.Commander("docker", "ps").Output()
+	if err != nil {
+		t.Fatalf("ps Output() failed: %v", err)
+	}
+	var containers []Container
+	if err := json.Unmarshal(out, &containers); err != nil {
+		t.Fatalf("ps output isn't valid JSON: %v", err)
+	}
+	if len(containers) != 1 || containers[0].Name != "web" {
+		t.Fatalf("ps containers = %v, want one container named web", containers)
+	}
+
+	out, err = d.Commander("docker", "inspect", "web").Output()
+	if err != nil || !strings.Contains(string(out), "nginx") {
+		t.Fatalf("inspect Output() = (%q, %v), want it to mention nginx", out, err)
+	}
+
+	if _, err := d.Commander("docker", "inspect", "missing").Output(); err == nil {
+		t.Error("inspect on a missing container should fail")
+	}
+}
+
+func Test_Daemon_RunAndBuild(t *testing.T) {
+	d := NewDaemon()
+
+	id, err := d.Commander("docker", "run", "-d", "nginx").Output()
+	if err != nil || !strings.HasPrefix(string(id), "fakecontainer") {
+		t.Fatalf("run Output() = (%q, %v), want a fakecontainer id", id, err)
+	}
+
+	out, err := d.Commander("docker", "build", "-t", "myapp:latest", ".").Output()
+	if err != nil || !strings.Contains(string(out), "myapp:latest") {
+		t.Fatalf("build Output() = (%q, %v), want it to mention the tag", out, err)
+	}
+}
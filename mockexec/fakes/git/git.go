@@ -0,0 +1,181 @@
+// Package git is a ready-made fake of the common git subcommands
+// (rev-parse, status, branch, clone, fetch), backed by a small in-memory
+// repo model, so callers wrapping git don't have to hand-write the same
+// "git returns the current branch name" mock over and over.
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"mock_exec/mockexec"
+)
+
+// Repo is an in-memory stand-in for a git working copy. Construct it with
+// NewRepo, shape it with AddBranch/Checkout/SetDirty/Commit, then use its
+// Commander as a package's shellCommander in tests.
+type Repo struct {
+	mu            sync.Mutex
+	currentBranch string
+	branches      map[string]bool
+	dirtyFiles    []string
+	remotes       map[string]string
+	fetched       []string
+}
+
+// NewRepo creates a Repo on initialBranch, which is also its sole branch
+// until AddBranch is called.
+func NewRepo(initialBranch string) *Repo {
+	return &Repo{
+		currentBranch: initialBranch,
+		branches:      map[string]bool{initialBranch: true},
+		remotes:       map[string]string{},
+	}
+}
+
+// AddBranch adds name to the repo's set of local branches.
+func (r *Repo) AddBranch(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.branches[name] = true
+}
+
+// Checkout switches the current branch, as if `git checkout <name>` had
+// succeeded. name must already exist (see AddBranch).
+func (r *Repo) Checkout(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.currentBranch = name
+}
+
+// SetDirty replaces the set of files `git status` reports as modified.
+func (r *Repo) SetDirty(files ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dirtyFiles = files
+}
+
+// Commit clears the dirty file set, as if all changes had been committed.
+func (r *Repo) Commit() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dirtyFiles = nil
+}
+
+// AddRemote records a remote's name and URL. The fake's `git clone` and
+// `git fetch` succeed for any URL or remote name, whether or not it was added.
+func (r *Repo) AddRemote(name, url string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remotes[name] = url
+}
+
+// Fetched returns the remotes that have been `git fetch`ed, in call order.
+func (r *Repo) Fetched() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.fetched...)
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (r *Repo) Commander(name string, args ...string) mockexec.IShellCommand {
+	if name != "git" {
+		return &mockexec.MockShellCommand{
+			Err: mockexec.NotFoundError(name),
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(args) == 0 {
+		return errCommand(fmt.Errorf("git: missing subcommand"))
+	}
+
+	switch args[0] {
+	case "rev-parse":
+		return r.revParse(args[1:])
+	case "status":
+		return r.status(args[1:])
+	case "branch":
+		return r.branch(args[1:])
+	case "clone":
+		return r.clone(args[1:])
+	case "fetch":
+		return r.fetch(args[1:])
+	default:
+		return errCommand(fmt.Errorf("git: fake does not implement subcommand %q", args[0]))
+	}
+}
+
+func (r *Repo) revParse(args []string) mockexec.IShellCommand {
+	for _, a := range args {
+		if a == "--abbrev-ref" {
+			return okCommand(r.currentBranch + "\n")
+		}
+	}
+	if len(args) > 0 && args[len(args)-1] == "HEAD" {
+		return okCommand(fmt.Sprintf("%x\n", []byte(r.currentBranch)))
+	}
+	return errCommand(fmt.Errorf("git: fake rev-parse does not understand args %v", args))
+}
+
+func (r *Repo) status(_ []string) mockexec.IShellCommand {
+	if len(r.dirtyFiles) == 0 {
+		return okCommand("")
+	}
+	lines := make([]string, len(r.dirtyFiles))
+	for i, f := range r.dirtyFiles {
+		lines[i] = " M " + f
+	}
+	return okCommand(strings.Join(lines, "\n") + "\n")
+}
+
+func (r *Repo) branch(_ []string) mockexec.IShellCommand {
+	names := make([]string, 0, len(r.branches))
+	for name := range r.branches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		if name == r.currentBranch {
+			b.WriteString("* " + name + "\n")
+		} else {
+			b.WriteString("  " + name + "\n")
+		}
+	}
+	return okCommand(b.String())
+}
+
+func (r *Repo) clone(args []string) mockexec.IShellCommand {
+	if len(args) == 0 {
+		return errCommand(fmt.Errorf("git: fake clone requires a URL"))
+	}
+	url := args[0]
+	dir := url
+	if len(args) > 1 {
+		dir = args[1]
+	}
+	return okCommand(fmt.Sprintf("Cloning into '%s'...\n", dir))
+}
+
+func (r *Repo) fetch(args []string) mockexec.IShellCommand {
+	remote := "origin"
+	if len(args) > 0 {
+		remote = args[0]
+	}
+	r.fetched = append(r.fetched, remote)
+	return okCommand("")
+}
+
+func okCommand(output string) mockexec.IShellCommand {
+	return &mockexec.MockShellCommand{Stdout: []byte(output)}
+}
+
+func errCommand(err error) mockexec.IShellCommand {
+	return &mockexec.MockShellCommand{Err: err}
+}
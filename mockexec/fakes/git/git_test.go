@@ -0,0 +1,50 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Repo_RevParseAndStatus(t *testing.T) {
+	repo := NewRepo("main")
+	repo.SetDirty("foo.go")
+
+	out, err := repo.Commander("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil || string(out) != "main\n" {
+		t.Fatalf("rev-parse Output() = (%q, %v), want (%q, nil)", out, err, "main\n")
+	}
+
+	out, err = repo.Commander("git", "status").Output()
+	if err != nil || !strings.Contains(string(out), "foo.go") {
+		t.Fatalf("status Output() = (%q, %v), want it to mention foo.go", out, err)
+	}
+
+	repo.Commit()
+	out, err = repo.Commander("git", "status").Output()
+	if err != nil || string(out) != "" {
+		t.Fatalf("status Output() after commit = (%q, %v), want (\"\", nil)", out, err)
+	}
+}
+
+func Test_Repo_BranchCloneFetch(t *testing.T) {
+	repo := NewRepo("main")
+	repo.AddBranch("feature")
+	repo.Checkout("feature")
+
+	out, err := repo.Commander("git", "branch").Output()
+	if err != nil || !strings.Contains(string(out), "* feature") {
+		t.Fatalf("branch Output() = (%q, %v), want it to mark feature current", out, err)
+	}
+
+	out, err = repo.Commander("git", "clone", "https://example.com/repo.git", "repo").Output()
+	if err != nil || !strings.Contains(string(out), "Cloning into 'repo'") {
+		t.Fatalf("clone Output() = (%q, %v), want it to mention the target dir", out, err)
+	}
+
+	if _, err := repo.Commander("git", "fetch", "origin").Output(); err != nil {
+		t.Fatalf("fetch Output() failed: %v", err)
+	}
+	if got := repo.Fetched(); len(got) != 1 || got[0] != "origin" {
+		t.Fatalf("Fetched() = %v, want [origin]", got)
+	}
+}
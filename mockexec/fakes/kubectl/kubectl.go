@@ -0,0 +1,187 @@
+// Package kubectl is a ready-made fake of the common kubectl subcommands
+// (get, apply, delete) against an in-memory object store, rendering
+// -o json/-o yaml the way a real cluster would, so operators that wrap
+// kubectl can be unit tested without a cluster.
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"mock_exec/mockexec"
+)
+
+// Object is a minimal stand-in for a Kubernetes object: enough structure to
+// exercise get/apply/delete and -o json/-o yaml rendering.
+type Object struct {
+	Kind      string                 `json:"kind" yaml:"kind"`
+	Name      string                 `json:"name" yaml:"name"`
+	Namespace string                 `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Spec      map[string]interface{} `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// Cluster is an in-memory stand-in for a Kubernetes API server's object
+// store. Construct it with NewCluster, seed it with Put, then use its
+// Commander as a package's shellCommander in tests.
+type Cluster struct {
+	mu      sync.Mutex
+	objects []Object
+}
+
+// NewCluster creates an empty Cluster.
+func NewCluster() *Cluster {
+	return &Cluster{}
+}
+
+// Put inserts or replaces an object by kind+namespace+name, as `kubectl
+// apply` would.
+func (c *Cluster) Put(o Object) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(o)
+}
+
+func (c *Cluster) put(o Object) {
+	for i, existing := range c.objects {
+		if existing.Kind == o.Kind && existing.Namespace == o.Namespace && existing.Name == o.Name {
+			c.objects[i] = o
+			return
+		}
+	}
+	c.objects = append(c.objects, o)
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (c *Cluster) Commander(name string, args ...string) mockexec.IShellCommand {
+	if name != "kubectl" {
+		return &mockexec.MockShellCommand{Err: mockexec.NotFoundError(name)}
+	}
+	if len(args) == 0 {
+		return errCommand(fmt.Errorf("kubectl: missing subcommand"))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch args[0] {
+	case "get":
+		return c.get(args[1:])
+	case "apply":
+		return c.apply(args[1:])
+	case "delete":
+		return c.delete(args[1:])
+	default:
+		return errCommand(fmt.Errorf("kubectl: fake does not implement subcommand %q", args[0]))
+	}
+}
+
+func (c *Cluster) get(args []string) mockexec.IShellCommand {
+	kind, objName, format := parseGetArgs(args)
+
+	var matches []Object
+	for _, o := range c.objects {
+		if o.Kind == kind && (objName == "" || o.Name == objName) {
+			matches = append(matches, o)
+		}
+	}
+	if objName != "" && len(matches) == 0 {
+		return errCommand(fmt.Errorf("Error from server (NotFound): %s %q not found", kind, objName))
+	}
+	return render(matches, format)
+}
+
+func (c *Cluster) apply(args []string) mockexec.IShellCommand {
+	path := ""
+	for i, a := range args {
+		if a == "-f" && i+1 < len(args) {
+			path = args[i+1]
+		}
+	}
+	if path == "" {
+		return errCommand(fmt.Errorf("kubectl: fake apply requires -f <path>"))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errCommand(err)
+	}
+	var o Object
+	if err := yaml.Unmarshal(data, &o); err != nil {
+		return errCommand(fmt.Errorf("kubectl: parsing %s: %w", path, err))
+	}
+	c.put(o)
+	return okCommand(fmt.Sprintf("%s/%s configured\n", lower(o.Kind), o.Name))
+}
+
+func (c *Cluster) delete(args []string) mockexec.IShellCommand {
+	kind, objName, _ := parseGetArgs(args)
+	for i, o := range c.objects {
+		if o.Kind == kind && o.Name == objName {
+			c.objects = append(c.objects[:i], c.objects[i+1:]...)
+			return okCommand(fmt.Sprintf("%s/%s deleted\n", lower(kind), objName))
+		}
+	}
+	return errCommand(fmt.Errorf("Error from server (NotFound): %s %q not found", kind, objName))
+}
+
+// parseGetArgs extracts "kubectl get <kind> [<name>] [-o <format>]"'s
+// positional kind/name and -o format.
+func parseGetArgs(args []string) (kind, name, format string) {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-o" && i+1 < len(args) {
+			format = args[i+1]
+			i++
+			continue
+		}
+		positional = append(positional, args[i])
+	}
+	if len(positional) > 0 {
+		kind = positional[0]
+	}
+	if len(positional) > 1 {
+		name = positional[1]
+	}
+	return kind, name, format
+}
+
+func render(objects []Object, format string) mockexec.IShellCommand {
+	switch format {
+	case "yaml":
+		out, err := yaml.Marshal(objects)
+		if err != nil {
+			return errCommand(err)
+		}
+		return okCommand(string(out))
+	case "json", "":
+		out, err := json.Marshal(objects)
+		if err != nil {
+			return errCommand(err)
+		}
+		return okCommand(string(out) + "\n")
+	default:
+		return errCommand(fmt.Errorf("kubectl: fake does not support -o %s", format))
+	}
+}
+
+func lower(s string) string {
+	if s == "" {
+		return s
+	}
+	b := []byte(s)
+	if b[0] >= 'A' && b[0] <= 'Z' {
+		b[0] += 'a' - 'A'
+	}
+	return string(b)
+}
+
+func okCommand(output string) mockexec.IShellCommand {
+	return &mockexec.MockShellCommand{Stdout: []byte(output)}
+}
+
+func errCommand(err error) mockexec.IShellCommand {
+	return &mockexec.MockShellCommand{Err: err}
+}
@@ -0,0 +1,64 @@
+package kubectl
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Cluster_GetJSONAndYAML(t *testing.T) {
+	c := NewCluster()
+	c.Put(Object{Kind: "Pod", Name: "web", Namespace: "default", Spec: map[string]interface{}{"image": "nginx"}})
+
+	out, err := c.Commander("kubectl", "get", "Pod", "web", "-o", "json").Output()
+	if err != nil {
+		t.Fatalf("get -o json Output() failed: %v", err)
+	}
+	var pods []Object
+	if err := json.Unmarshal(out, &pods); err != nil {
+		t.Fatalf("get -o json output isn't valid JSON: %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "web" {
+		t.Fatalf("pods = %v, want one pod named web", pods)
+	}
+
+	out, err = c.Commander("kubectl", "get", "Pod", "web", "-o", "yaml").Output()
+	if err != nil || !strings.Contains(string(out), "name: web") {
+		t.Fatalf("get -o yaml Output() = (%q, %v), want it to mention the pod name", out, err)
+	}
+
+	if _, err := c.Commander("kubectl", "get", "Pod", "missing", "-o", "json").Output(); err == nil {
+		t.Error("get on a missing object should fail")
+	}
+}
+
+func Test_Cluster_ApplyAndDelete(t *testing.T) {
+	c := NewCluster()
+
+	manifest := "kind: Pod\nname: web\nnamespace: default\n"
+	path := filepath.Join(t.TempDir(), "pod.yaml")
+	if err := os.WriteFile(path, []byte(manifest), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	out, err := c.Commander("kubectl", "apply", "-f", path).Output()
+	if err != nil || !strings.Contains(string(out), "pod/web configured") {
+		t.Fatalf("apply Output() = (%q, %v), want it to confirm pod/web configured", out, err)
+	}
+
+	out, err = c.Commander("kubectl", "get", "Pod", "web", "-o", "json").Output()
+	if err != nil || !strings.Contains(string(out), "web") {
+		t.Fatalf("get after apply Output() = (%q, %v), want it to find the applied pod", out, err)
+	}
+
+	out, err = c.Commander("kubectl", "delete", "Pod", "web").Output()
+	if err != nil || !strings.Contains(string(out), "pod/web deleted") {
+		t.Fatalf("delete Output() = (%q, %v), want it to confirm pod/web deleted", out, err)
+	}
+
+	if _, err := c.Commander("kubectl", "get", "Pod", "web", "-o", "json").Output(); err == nil {
+		t.Error("get after delete should fail")
+	}
+}
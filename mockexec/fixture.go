@@ -0,0 +1,171 @@
+package mockexec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"text/template"
+	"time"
+)
+
+// FixtureEntry declares one scripted response. Args is matched exactly
+// unless ArgsPattern is set, in which case it's matched as a regular
+// expression against the space-joined argument list, so one entry can cover
+// many similar invocations.
+//
+// Stdout/Stderr hold UTF-8 text. For binary output (protobuf, gzip, etc.),
+// set StdoutBase64/StderrBase64 instead; they take precedence when present
+// and are decoded to raw bytes, never passed through a string.
+type FixtureEntry struct {
+	Command      string   `json:"command"`
+	Args         []string `json:"args,omitempty"`
+	ArgsPattern  string   `json:"argsPattern,omitempty"`
+	Stdout       string   `json:"stdout"`
+	Stderr       string   `json:"stderr"`
+	StdoutBase64 string   `json:"stdoutBase64,omitempty"`
+	StderrBase64 string   `json:"stderrBase64,omitempty"`
+	ExitCode     int      `json:"exitCode"`
+	DelayMS      int      `json:"delayMs,omitempty"`
+
+	// Template, if true, renders Stdout/Stderr as Go text/template source
+	// against a fixtureInvocation before serving them, so one entry can
+	// respond to e.g. "git rev-parse <branch>" by echoing {{index .Args 1}}
+	// back, instead of needing one entry per branch name. Has no effect on
+	// StdoutBase64/StderrBase64.
+	Template bool `json:"template,omitempty"`
+}
+
+// fixtureInvocation is the data available to a Template fixture entry's
+// Stdout/Stderr.
+type fixtureInvocation struct {
+	Args []string
+	Dir  string
+	Env  []string
+}
+
+// render runs text through text/template with inv as its data, for a
+// Template fixture entry's Stdout/Stderr.
+func render(text string, inv fixtureInvocation) (string, error) {
+	tmpl, err := template.New("fixture").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("mockexec: parsing fixture template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, inv); err != nil {
+		return "", fmt.Errorf("mockexec: executing fixture template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// stdoutBytes returns the entry's stdout payload as raw bytes, decoding
+// StdoutBase64 if set, rendering Stdout as a template against inv if
+// Template is set, otherwise treating Stdout as literal UTF-8 text.
+func (e FixtureEntry) stdoutBytes(inv fixtureInvocation) ([]byte, error) {
+	if e.StdoutBase64 != "" {
+		return base64.StdEncoding.DecodeString(e.StdoutBase64)
+	}
+	if e.Template {
+		out, err := render(e.Stdout, inv)
+		return []byte(out), err
+	}
+	return []byte(e.Stdout), nil
+}
+
+// stderrBytes is stdoutBytes for the stderr payload.
+func (e FixtureEntry) stderrBytes(inv fixtureInvocation) ([]byte, error) {
+	if e.StderrBase64 != "" {
+		return base64.StdEncoding.DecodeString(e.StderrBase64)
+	}
+	if e.Template {
+		out, err := render(e.Stderr, inv)
+		return []byte(out), err
+	}
+	return []byte(e.Stderr), nil
+}
+
+// FixtureCommander serves responses declared in a fixture file, so large
+// test scenarios don't have to be hard-coded in Go source.
+type FixtureCommander struct {
+	entries []FixtureEntry
+}
+
+// LoadFixtures reads a JSON file containing a list of FixtureEntry and
+// returns a commander that serves them.
+func LoadFixtures(path string) (*FixtureCommander, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []FixtureEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("mockexec: parsing fixtures %s: %w", path, err)
+	}
+	return &FixtureCommander{entries: entries}, nil
+}
+
+func (e FixtureEntry) matchesArgs(args []string) bool {
+	if e.ArgsPattern != "" {
+		joined := ""
+		for i, a := range args {
+			if i > 0 {
+				joined += " "
+			}
+			joined += a
+		}
+		ok, err := regexp.MatchString(e.ArgsPattern, joined)
+		return err == nil && ok
+	}
+	if len(e.Args) != len(args) {
+		return false
+	}
+	for i := range e.Args {
+		if e.Args[i] != args[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (fc *FixtureCommander) Commander(name string, arg ...string) IShellCommand {
+	for _, e := range fc.entries {
+		if e.Command != name || !e.matchesArgs(arg) {
+			continue
+		}
+		entry := e
+		cmd := &MockShellCommand{
+			ExitCodeValue: entry.ExitCode,
+			// ProcessStateValue keeps Wait/ProcessState in agreement with
+			// ExitCode/the ExitError OutputterFunc returns below, so a
+			// fixture recorded on one OS (just a plain exit code - there's
+			// nothing Unix/Windows-specific about that number) replays
+			// consistently regardless of which OS runs the test: ExitError
+			// itself picks the right shell per runtime.GOOS to fabricate a
+			// genuine, platform-native *exec.ExitError/os.ProcessState.
+			ProcessStateValue: FakeProcessState{ExitCodeValue: entry.ExitCode, SuccessValue: entry.ExitCode == 0},
+		}
+		cmd.OutputterFunc = func() ([]byte, error) {
+			if entry.DelayMS > 0 {
+				time.Sleep(time.Duration(entry.DelayMS) * time.Millisecond)
+			}
+			inv := fixtureInvocation{Args: arg, Dir: cmd.Dir(), Env: cmd.Environ()}
+			stdout, err := entry.stdoutBytes(inv)
+			if err != nil {
+				return nil, fmt.Errorf("mockexec: rendering stdout: %w", err)
+			}
+			if entry.ExitCode != 0 {
+				stderr, err := entry.stderrBytes(inv)
+				if err != nil {
+					return nil, fmt.Errorf("mockexec: rendering stderr: %w", err)
+				}
+				return stdout, ExitError(entry.ExitCode, stderr)
+			}
+			return stdout, nil
+		}
+		return cmd
+	}
+	panic(fmt.Sprintf("mockexec: no fixture matches command %s", QuoteCommandLine(name, arg)))
+}
@@ -0,0 +1,114 @@
+package mockexec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_LoadFixtures_ServesDeclaredResponses(t *testing.T) {
+	fixturePath := filepath.Join(t.TempDir(), "fixtures.json")
+	const fixtureJSON = `[
+		{"command": "git", "args": ["rev-parse", "--abbrev-ref", "HEAD"], "stdout": "main\n"},
+		{"command": "curl", "argsPattern": "^--file=.*\\.json https://", "stdout": "{}\n"}
+	]`
+	if err := os.WriteFile(fixturePath, []byte(fixtureJSON), 0o644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+
+	fc, err := LoadFixtures(fixturePath)
+	if err != nil {
+		t.Fatalf("LoadFixtures() failed: %v", err)
+	}
+
+	out, err := fc.Commander("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil || string(out) != "main\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "main\n")
+	}
+
+	out, err = fc.Commander("curl", "--file=data.json", "https://example.com").Output()
+	if err != nil || string(out) != "{}\n" {
+		t.Errorf("pattern-matched Output() = (%q, %v), want (%q, nil)", out, err, "{}\n")
+	}
+}
+
+func Test_LoadFixtures_NonzeroExitCode_PopulatesProcessState(t *testing.T) {
+	fixturePath := filepath.Join(t.TempDir(), "fixtures.json")
+	const fixtureJSON = `[
+		{"command": "deploy", "stdout": "", "stderr": "boom\n", "exitCode": 3}
+	]`
+	if err := os.WriteFile(fixturePath, []byte(fixtureJSON), 0o644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+
+	fc, err := LoadFixtures(fixturePath)
+	if err != nil {
+		t.Fatalf("LoadFixtures() failed: %v", err)
+	}
+
+	cmd := fc.Commander("deploy")
+	if _, err := cmd.Output(); err == nil {
+		t.Fatal("Output() = nil error, want the fixture's nonzero exit code to surface as an error")
+	}
+	if cmd.ExitCode() != 3 {
+		t.Errorf("ExitCode() = %d, want 3", cmd.ExitCode())
+	}
+
+	ps := cmd.ProcessState()
+	if ps == nil {
+		t.Fatal("ProcessState() = nil, want it populated from the fixture's exitCode")
+	}
+	if ps.ExitCode() != 3 || ps.Success() {
+		t.Errorf("ProcessState() = {ExitCode: %d, Success: %v}, want {3, false}", ps.ExitCode(), ps.Success())
+	}
+}
+
+func Test_LoadFixtures_TemplatedOutput_EchoesArgsPerInvocation(t *testing.T) {
+	fixturePath := filepath.Join(t.TempDir(), "fixtures.json")
+	const fixtureJSON = `[
+		{"command": "git", "args": ["rev-parse", "my-branch"], "template": true, "stdout": "{{index .Args 1}}\n"},
+		{"command": "git", "args": ["rev-parse", "other-branch"], "template": true, "stdout": "{{index .Args 1}}\n"}
+	]`
+	if err := os.WriteFile(fixturePath, []byte(fixtureJSON), 0o644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+
+	fc, err := LoadFixtures(fixturePath)
+	if err != nil {
+		t.Fatalf("LoadFixtures() failed: %v", err)
+	}
+
+	out, err := fc.Commander("git", "rev-parse", "my-branch").Output()
+	if err != nil || string(out) != "my-branch\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "my-branch\n")
+	}
+
+	out, err = fc.Commander("git", "rev-parse", "other-branch").Output()
+	if err != nil || string(out) != "other-branch\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "other-branch\n")
+	}
+}
+
+func Test_LoadFixtures_BinaryOutput(t *testing.T) {
+	fixturePath := filepath.Join(t.TempDir(), "fixtures.json")
+	binary := []byte{0x1f, 0x8b, 0x00, 0xff, 0x00, 0x01}
+	const fixtureJSON = `[
+		{"command": "dump", "stdoutBase64": "H4sA/wAB"}
+	]`
+	if err := os.WriteFile(fixturePath, []byte(fixtureJSON), 0o644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+
+	fc, err := LoadFixtures(fixturePath)
+	if err != nil {
+		t.Fatalf("LoadFixtures() failed: %v", err)
+	}
+
+	out, err := fc.Commander("dump").Output()
+	if err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+	if string(out) != string(binary) {
+		t.Errorf("Output() = %v, want %v", out, binary)
+	}
+}
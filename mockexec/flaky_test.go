@@ -0,0 +1,28 @@
+package mockexec
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_Expectation_Flaky(t *testing.T) {
+	mc := NewMockCommander()
+	failErr := errors.New("connection reset")
+	mc.ExpectCommand("curl", "https://example.com").Flaky(2, failErr, Response{Output: "ok\n"})
+
+	for i := 0; i < 2; i++ {
+		if _, err := mc.Commander("curl", "https://example.com").Output(); err != failErr {
+			t.Fatalf("attempt %d: Output() error = %v, want %v", i+1, err, failErr)
+		}
+	}
+
+	out, err := mc.Commander("curl", "https://example.com").Output()
+	if err != nil || string(out) != "ok\n" {
+		t.Fatalf("attempt 3: Output() = (%q, %v), want (%q, nil)", out, err, "ok\n")
+	}
+
+	exp := mc.expectations[0]
+	if exp.Attempts() != 3 {
+		t.Errorf("Attempts() = %d, want 3", exp.Attempts())
+	}
+}
@@ -0,0 +1,22 @@
+package mockexec
+
+// On is a more fluent alias for ExpectCommand, for a DSL reading like
+// m.On("git", "status").InDir("mydir").ReturnStdout("clean\n").
+func (mc *MockCommander) On(name string, args ...interface{}) *Expectation {
+	return mc.ExpectCommand(name, args...)
+}
+
+// InDir is a more fluent alias for WithDir.
+func (e *Expectation) InDir(dir string) *Expectation {
+	return e.WithDir(dir)
+}
+
+// ReturnStdout is a more fluent alias for Return(output, nil).
+func (e *Expectation) ReturnStdout(output string) *Expectation {
+	return e.Return(output, nil)
+}
+
+// ReturnError is a more fluent alias for Return("", err).
+func (e *Expectation) ReturnError(err error) *Expectation {
+	return e.Return("", err)
+}
@@ -0,0 +1,25 @@
+package mockexec
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_MockCommander_FluentDSL(t *testing.T) {
+	m := NewMockCommander()
+	m.On("git", "rev-parse", "--abbrev-ref", "HEAD").InDir("mydir").ReturnStdout("main\n")
+	m.On("git", Any()).ReturnError(errors.New("unexpected git invocation"))
+
+	cmd := m.Commander("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.SetDir("mydir")
+	out, err := cmd.Output()
+	if err != nil || string(out) != "main\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "main\n")
+	}
+
+	if _, err := m.Commander("git", "push").Output(); err == nil {
+		t.Error("unexpected git invocation should have errored")
+	}
+
+	m.Verify(t)
+}
@@ -0,0 +1,34 @@
+package mockexec
+
+import "fmt"
+
+// FuzzDispatch drives fn with name and args, recovering any panic into an
+// error instead of letting it escape as a fuzz crasher, and checking that
+// calling Output on the result doesn't itself panic. It's meant to be
+// called from inside a native Go fuzz target (a Fuzz*(f *testing.F)
+// function's f.Fuzz callback) registered against a commander under test -
+// e.g. a MockCommander with a handful of expectations, or SetDefault - to
+// harden the dispatch/matching layer against malformed or adversarial
+// input the type system doesn't rule out, such as an empty name or
+// mismatched arg count.
+//
+// It returns an error describing what went wrong rather than failing a
+// *testing.T directly, so callers can decide whether a given panic is
+// expected (e.g. MockCommander intentionally panics on an unmatched call)
+// and filter it out before reporting a fuzz failure.
+func FuzzDispatch(fn ShellCommanderFunc, name string, args []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("mockexec: FuzzDispatch(%q, %v) panicked: %v", name, args, r)
+		}
+	}()
+
+	cmd := fn(name, args...)
+	if cmd == nil {
+		return fmt.Errorf("mockexec: FuzzDispatch(%q, %v): commander returned a nil IShellCommand", name, args)
+	}
+	if _, outErr := cmd.Output(); outErr != nil {
+		_ = outErr // a non-nil error is a well-formed Response; only a panic is a bug
+	}
+	return nil
+}
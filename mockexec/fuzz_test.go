@@ -0,0 +1,39 @@
+package mockexec
+
+import "testing"
+
+func Test_FuzzDispatch_RecoversPanic(t *testing.T) {
+	mc := NewMockCommander()
+
+	err := FuzzDispatch(mc.Commander, "unexpected", nil)
+	if err == nil {
+		t.Fatal("FuzzDispatch() = nil, want error describing the recovered panic")
+	}
+}
+
+func Test_FuzzDispatch_NoErrorOnWellFormedResponse(t *testing.T) {
+	mc := NewMockCommander()
+	mc.DefaultReturn("ok", nil)
+
+	if err := FuzzDispatch(mc.Commander, "git", []string{"status"}); err != nil {
+		t.Errorf("FuzzDispatch() = %v, want nil", err)
+	}
+}
+
+// FuzzDispatch_MockCommanderWithDefault hardens the dispatch/matching layer
+// itself: with a default handler installed, no (name, arg) pair should
+// ever panic, regardless of how malformed the input is.
+func FuzzDispatch_MockCommanderWithDefault(f *testing.F) {
+	f.Add("git", "status")
+	f.Add("", "")
+	f.Add("curl", "--file=x.json")
+
+	f.Fuzz(func(t *testing.T, name string, arg string) {
+		mc := NewMockCommander()
+		mc.DefaultReturn("ok", nil)
+
+		if err := FuzzDispatch(mc.Commander, name, []string{arg}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package mockexec
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("mockexec.update", false, "update mockexec golden files instead of comparing against them")
+
+// AssertGoldenCalls serializes calls (one "name arg1 arg2 ..." line each)
+// and compares the result against the golden file at path, failing t on any
+// difference. Run the test with -mockexec.update to (re)write path instead
+// of comparing, the usual golden-file convention.
+func AssertGoldenCalls(t *testing.T, path string, calls []CallRecord) {
+	var buf bytes.Buffer
+	for _, c := range calls {
+		fmt.Fprintf(&buf, "%s %s\n", c.Name, strings.Join(c.Args, " "))
+	}
+
+	if *updateGolden {
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("mockexec: failed to update golden file %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("mockexec: failed to read golden file %s (run with -mockexec.update to create it): %v", path, err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("mockexec: invocations diverged from golden file %s:\ngot:\n%s\nwant:\n%s", path, buf.String(), want)
+	}
+}
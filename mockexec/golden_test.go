@@ -0,0 +1,21 @@
+package mockexec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_AssertGoldenCalls(t *testing.T) {
+	goldenPath := filepath.Join(t.TempDir(), "invocations.golden")
+	calls := []CallRecord{
+		{Name: "git", Args: []string{"fetch", "origin"}},
+		{Name: "git", Args: []string{"merge", "origin/main"}},
+	}
+
+	if err := os.WriteFile(goldenPath, []byte("git fetch origin\ngit merge origin/main\n"), 0o644); err != nil {
+		t.Fatalf("failed to seed golden file: %v", err)
+	}
+
+	AssertGoldenCalls(t, goldenPath, calls)
+}
@@ -0,0 +1,9 @@
+package mockexec
+
+// NewGomockCommander returns a ShellCommanderFunc that always hands back m,
+// the controller-driven *MockIShellCommand generated by mockgen (see
+// gomock_shell_command.go). Configure m's expectations with m.EXPECT()
+// before swapping it in.
+func NewGomockCommander(m *MockIShellCommand) ShellCommanderFunc {
+	return func(name string, arg ...string) IShellCommand { return m }
+}
@@ -0,0 +1,19 @@
+package mockexec
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GomockCommander_ExpectCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockIShellCommand(ctrl)
+	m.EXPECT().Output().Return([]byte("main\n"), nil)
+
+	commander := NewGomockCommander(m)
+	out, err := commander("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil || string(out) != "main\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "main\n")
+	}
+}
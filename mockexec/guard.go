@@ -0,0 +1,16 @@
+package mockexec
+
+import "strings"
+
+// GuardRealExec returns a ShellCommanderFunc that fails t (via
+// TestingT.Fatalf) with the attempted command line instead of running
+// anything, so swapping it in for a package's shellCommander guarantees no
+// unit test accidentally shells out to a real binary on the developer
+// machine or CI.
+func GuardRealExec(t TestingT) ShellCommanderFunc {
+	return func(name string, arg ...string) IShellCommand {
+		cmdline := append([]string{name}, arg...)
+		t.Fatalf("mockexec: test attempted to run a real command: %s", strings.Join(cmdline, " "))
+		return &MockShellCommand{}
+	}
+}
@@ -0,0 +1,14 @@
+package mockexec
+
+import "testing"
+
+func Test_GuardRealExec_FailsOnAttemptedRealCommand(t *testing.T) {
+	fake := &fakeTestingT{}
+	commander := GuardRealExec(fake)
+
+	commander("rm", "-rf", "/")
+
+	if !fake.failed {
+		t.Error("expected Fatalf to be called for an attempted real command")
+	}
+}
@@ -0,0 +1,39 @@
+package mockexec
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func Test_MockShellCommand_StdoutPipeWithDelays(t *testing.T) {
+	cmd := &MockShellCommand{
+		StdoutChunks:      []string{"one\n", "two\n", "three\n"},
+		StdoutChunkDelays: []time.Duration{0, 10 * time.Millisecond},
+	}
+
+	pipe, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("StdoutPipe() failed: %v", err)
+	}
+	defer pipe.Close()
+
+	start := time.Now()
+	buf := make([]byte, 0)
+	chunk := make([]byte, 64)
+	for {
+		n, readErr := pipe.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if readErr == io.EOF {
+			break
+		}
+	}
+	elapsed := time.Since(start)
+
+	if string(buf) != "one\ntwo\nthree\n" {
+		t.Errorf("streamed content = %q, want %q", buf, "one\ntwo\nthree\n")
+	}
+	if elapsed < 10*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least the configured 10ms delay", elapsed)
+	}
+}
@@ -0,0 +1,40 @@
+package mockexec
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_MockShellCommand_BlockUntilContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	cmd := &MockShellCommand{
+		ctx:           ctx,
+		BlockUntil:    make(chan struct{}), // never closed: this command hangs forever
+		OutputterFunc: func() ([]byte, error) { return []byte("too late"), nil },
+	}
+
+	if _, err := cmd.Output(); err != context.DeadlineExceeded {
+		t.Errorf("Output() = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func Test_MockShellCommand_BlockUntilUnblocked(t *testing.T) {
+	unblock := make(chan struct{})
+	cmd := &MockShellCommand{
+		BlockUntil:    unblock,
+		OutputterFunc: func() ([]byte, error) { return []byte("done"), nil },
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		close(unblock)
+	}()
+
+	out, err := cmd.Output()
+	if err != nil || string(out) != "done" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "done")
+	}
+}
@@ -0,0 +1,73 @@
+package mockexec
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// This file implements the classic Go stdlib "helper process" pattern for
+// tests that need a real subprocess (reading real pipes, checking real
+// PIDs) rather than an in-process fake. The test binary re-execs itself
+// with GO_WANT_HELPER_PROCESS=1 and -test.run=<helper test name>; the
+// helper test looks up a registered handler by the fake command's name and
+// runs it in place of a real binary.
+
+const helperProcessEnvVar = "GO_WANT_HELPER_PROCESS"
+
+var helperHandlers = map[string]func(args []string){}
+
+// RegisterHelperProcess installs fn as the behavior for the fake command
+// name, to be run inside the re-exec'd helper process. Call RunHelperProcess
+// from a TestXxx function (conventionally named TestHelperProcess) to
+// dispatch into it.
+func RegisterHelperProcess(name string, fn func(args []string)) {
+	helperHandlers[name] = fn
+}
+
+// NewHelperProcessCommander returns a ShellCommanderFunc whose commands
+// re-exec the current test binary as a helper process running the test
+// named helperTestName (e.g. "TestHelperProcess"), which must call
+// RunHelperProcess.
+func NewHelperProcessCommander(helperTestName string) ShellCommanderFunc {
+	return func(name string, arg ...string) IShellCommand {
+		cs := append([]string{name}, arg...)
+		helperArgs := append([]string{"-test.run=" + helperTestName, "--"}, cs...)
+		cmd := exec.Command(os.Args[0], helperArgs...)
+		cmd.Env = append(os.Environ(), helperProcessEnvVar+"=1")
+		return ExecShellCommand{Cmd: cmd}
+	}
+}
+
+// RunHelperProcess dispatches to the handler registered via
+// RegisterHelperProcess for the fake command name passed after "--" on the
+// re-exec'd command line. It is a no-op (so the surrounding test continues
+// normally) unless GO_WANT_HELPER_PROCESS=1 is set, which only the
+// commander returned by NewHelperProcessCommander sets.
+func RunHelperProcess() {
+	if os.Getenv(helperProcessEnvVar) != "1" {
+		return
+	}
+	defer os.Exit(0)
+
+	args := os.Args
+	for len(args) > 0 {
+		if args[0] == "--" {
+			args = args[1:]
+			break
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "mockexec: no fake command given to helper process")
+		os.Exit(2)
+	}
+
+	name, cmdArgs := args[0], args[1:]
+	handler, ok := helperHandlers[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "mockexec: no helper process handler registered for %q\n", name)
+		os.Exit(2)
+	}
+	handler(cmdArgs)
+}
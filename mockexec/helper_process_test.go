@@ -0,0 +1,29 @@
+package mockexec
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestHelperProcess isn't a real test: it's the re-exec target that
+// RunHelperProcess dispatches from. See NewHelperProcessCommander. Handlers
+// are registered here (rather than in the test that uses them) because this
+// is the only code that also runs inside the re-exec'd child process.
+func TestHelperProcess(t *testing.T) {
+	RegisterHelperProcess("fakegit", func(args []string) {
+		fmt.Fprintln(os.Stdout, "main")
+	})
+	RunHelperProcess()
+}
+
+func Test_HelperProcessCommander_RunsRealSubprocess(t *testing.T) {
+	commander := NewHelperProcessCommander("TestHelperProcess")
+	out, err := commander("fakegit", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+	if got := string(out); got != "main\n" {
+		t.Errorf("Output() = %q, want %q", got, "main\n")
+	}
+}
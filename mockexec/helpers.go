@@ -0,0 +1,78 @@
+package mockexec
+
+import (
+	"context"
+)
+
+// ShellCommanderFunc matches the signature of NewExecShellCommander, i.e.
+// what a package-level commander var should be typed as.
+type ShellCommanderFunc func(name string, arg ...string) IShellCommand
+
+// ShellCommanderContextFunc matches the signature of NewExecShellCommanderContext.
+type ShellCommanderContextFunc func(ctx context.Context, name string, arg ...string) IShellCommand
+
+// NewMockShellCommanderForOutput returns a ShellCommanderFunc whose commands
+// always produce the given output/error pair, logging each invocation via t.
+func NewMockShellCommanderForOutput(output string, err error, t Logger) ShellCommanderFunc {
+	return func(name string, arg ...string) IShellCommand {
+		logf(t, "exec.Command() called with %v and %v", name, arg)
+		outputterFunc := func() ([]byte, error) {
+			if err == nil {
+				logf(t, "Output obtained for %v %v", name, arg)
+			} else {
+				logf(t, "Failed to get Output for %v %v", name, arg)
+			}
+			return []byte(output), err
+		}
+		return &MockShellCommand{
+			OutputterFunc: outputterFunc,
+		}
+	}
+}
+
+// NewMockShellCommanderForBytes is NewMockShellCommanderForOutput's
+// binary-safe counterpart: output is passed through verbatim as raw bytes,
+// so callers producing protobuf, gzip, or other non-UTF-8 payloads don't
+// have to round-trip through a string.
+func NewMockShellCommanderForBytes(output []byte, err error, t Logger) ShellCommanderFunc {
+	return func(name string, arg ...string) IShellCommand {
+		logf(t, "exec.Command() called with %v and %v", name, arg)
+		return &MockShellCommand{
+			OutputterFunc: func() ([]byte, error) { return output, err },
+		}
+	}
+}
+
+// NewMockLookPath returns a LookPathFunc that always resolves to path, for
+// tests exercising the "binary found at a custom path" case.
+func NewMockLookPath(path string, err error) LookPathFunc {
+	return func(file string) (string, error) { return path, err }
+}
+
+// NewMockLookPathNotFound returns a LookPathFunc that always fails as if
+// the binary weren't on PATH (see NotFoundError).
+func NewMockLookPathNotFound() LookPathFunc {
+	return func(file string) (string, error) { return "", NotFoundError(file) }
+}
+
+// NewMockShellCommanderNotFound returns a ShellCommanderFunc whose commands
+// always fail as if the binary weren't on PATH (see NotFoundError).
+func NewMockShellCommanderNotFound() ShellCommanderFunc {
+	return func(name string, arg ...string) IShellCommand {
+		err := NotFoundError(name)
+		return &MockShellCommand{
+			OutputterFunc: func() ([]byte, error) { return nil, err },
+		}
+	}
+}
+
+// NewMockShellCommanderContextForOutput is NewMockShellCommanderForOutput's
+// context-aware counterpart: the returned commands honor ctx cancellation.
+func NewMockShellCommanderContextForOutput(output string, err error) ShellCommanderContextFunc {
+	return func(ctx context.Context, name string, arg ...string) IShellCommand {
+		return &MockShellCommand{
+			ctx:           ctx,
+			OutputterFunc: func() ([]byte, error) { return []byte(output), err },
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package mockexec
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_NewMockShellCommanderForBytes(t *testing.T) {
+	binary := []byte{0x1f, 0x8b, 0x00, 0xff, 0x00, 0x01}
+	commander := NewMockShellCommanderForBytes(binary, nil, t)
+
+	out, err := commander("dump").Output()
+	if err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+	if !bytes.Equal(out, binary) {
+		t.Errorf("Output() = %v, want %v", out, binary)
+	}
+}
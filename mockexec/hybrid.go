@@ -0,0 +1,40 @@
+package mockexec
+
+// HybridCommander routes some command names to the real exec-backed
+// commander and others to a fake, for integration-ish tests that want a
+// handful of trustworthy binaries (uname, ls, ...) to really run while
+// everything else under test stays mocked.
+type HybridCommander struct {
+	fake    ShellCommanderFunc
+	useReal func(name string) bool
+}
+
+// NewAllowlistCommander routes names in allow to the real commander and
+// everything else to fake.
+func NewAllowlistCommander(fake ShellCommanderFunc, allow ...string) *HybridCommander {
+	allowed := toSet(allow)
+	return &HybridCommander{fake: fake, useReal: func(name string) bool { return allowed[name] }}
+}
+
+// NewDenylistCommander routes names in deny to fake and everything else to
+// the real commander.
+func NewDenylistCommander(fake ShellCommanderFunc, deny ...string) *HybridCommander {
+	denied := toSet(deny)
+	return &HybridCommander{fake: fake, useReal: func(name string) bool { return !denied[name] }}
+}
+
+func toSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (h *HybridCommander) Commander(name string, arg ...string) IShellCommand {
+	if h.useReal(name) {
+		return NewExecShellCommander(name, arg...)
+	}
+	return h.fake(name, arg...)
+}
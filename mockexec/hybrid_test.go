@@ -0,0 +1,25 @@
+package mockexec
+
+import (
+	"runtime"
+	"testing"
+)
+
+func Test_HybridCommander_Allowlist(t *testing.T) {
+	uname := "uname"
+	if runtime.GOOS == "windows" {
+		t.Skip("uname is not available on windows")
+	}
+
+	fake := NewMockShellCommanderForOutput("faked", nil, t)
+	h := NewAllowlistCommander(fake, uname)
+
+	if _, err := h.Commander(uname).Output(); err != nil {
+		t.Errorf("real %v invocation failed: %v", uname, err)
+	}
+
+	out, err := h.Commander("terraform", "apply").Output()
+	if err != nil || string(out) != "faked" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "faked")
+	}
+}
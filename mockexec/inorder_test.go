@@ -0,0 +1,43 @@
+package mockexec
+
+import "testing"
+
+func Test_InOrder_EnforcesRelativeOrder(t *testing.T) {
+	mc := NewMockCommander()
+	fetch := mc.ExpectCommand("git", "fetch").Return("", nil)
+	merge := mc.ExpectCommand("git", "merge").Return("", nil)
+	InOrder(fetch, merge)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Commander to panic when merge runs before fetch")
+		}
+	}()
+	mc.Commander("git", "merge")
+}
+
+func Test_InOrder_AllowsCorrectOrder(t *testing.T) {
+	mc := NewMockCommander()
+	fetch := mc.ExpectCommand("git", "fetch").Return("", nil)
+	merge := mc.ExpectCommand("git", "merge").Return("", nil)
+	InOrder(fetch, merge)
+
+	mc.Commander("git", "fetch")
+	mc.Commander("git", "merge")
+
+	mc.Verify(t)
+}
+
+func Test_InOrder_LeavesUnrelatedCallsUnordered(t *testing.T) {
+	mc := NewMockCommander()
+	fetch := mc.ExpectCommand("git", "fetch").Return("", nil)
+	merge := mc.ExpectCommand("git", "merge").Return("", nil)
+	InOrder(fetch, merge)
+	mc.ExpectCommand("git", "status").Return("clean", nil)
+
+	mc.Commander("git", "status")
+	mc.Commander("git", "fetch")
+	mc.Commander("git", "merge")
+
+	mc.Verify(t)
+}
@@ -0,0 +1,37 @@
+package mockexec
+
+import "io"
+
+// repeatingReader emits size bytes of pattern repeated, without ever
+// materializing the full output in memory, for stress-testing consumers
+// that must not buffer an entire command's (possibly multi-hundred-MB)
+// output.
+type repeatingReader struct {
+	pattern   []byte
+	remaining int64
+	pos       int
+}
+
+func (r *repeatingReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		return 0, io.EOF
+	}
+	n := 0
+	for n < len(p) && r.remaining > 0 {
+		p[n] = r.pattern[r.pos%len(r.pattern)]
+		n++
+		r.pos++
+		r.remaining--
+	}
+	return n, nil
+}
+
+// NewLargeOutputReader returns an io.Reader that lazily produces size bytes
+// by repeating pattern, generating each chunk on demand rather than
+// allocating the whole output up front.
+func NewLargeOutputReader(size int64, pattern []byte) io.Reader {
+	if len(pattern) == 0 {
+		pattern = []byte{'x'}
+	}
+	return &repeatingReader{pattern: pattern, remaining: size}
+}
@@ -0,0 +1,41 @@
+package mockexec
+
+import (
+	"io"
+	"testing"
+)
+
+func Test_NewLargeOutputReader(t *testing.T) {
+	const size = 10 * 1024 * 1024 // 10MB, large enough to require multiple Read calls
+	r := NewLargeOutputReader(size, []byte("ab"))
+
+	var total int64
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		total += int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() failed: %v", err)
+		}
+		if n > 0 && buf[0] != 'a' && buf[0] != 'b' {
+			t.Fatalf("unexpected byte %q, want pattern byte", buf[0])
+		}
+	}
+	if total != size {
+		t.Errorf("total bytes read = %d, want %d", total, size)
+	}
+}
+
+func Test_NewLargeOutputReader_DefaultPattern(t *testing.T) {
+	r := NewLargeOutputReader(3, nil)
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if string(out) != "xxx" {
+		t.Errorf("output = %q, want %q", out, "xxx")
+	}
+}
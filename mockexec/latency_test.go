@@ -0,0 +1,49 @@
+package mockexec
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Expectation_After_DelaysOutput(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("slow-tool").Return("done\n", nil).After(20 * time.Millisecond)
+
+	start := time.Now()
+	out, err := mc.Commander("slow-tool").Output()
+	elapsed := time.Since(start)
+
+	if err != nil || string(out) != "done\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "done\n")
+	}
+	if elapsed < 20*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least the configured 20ms delay", elapsed)
+	}
+}
+
+func Test_Expectation_After_AppliesToQueuedResponse(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("slow-tool").
+		ReturnOnce("first\n", nil).After(10*time.Millisecond).
+		Return("second\n", nil)
+
+	start := time.Now()
+	out, err := mc.Commander("slow-tool").Output()
+	elapsed := time.Since(start)
+	if err != nil || string(out) != "first\n" {
+		t.Fatalf("first call Output() = (%q, %v), want (%q, nil)", out, err, "first\n")
+	}
+	if elapsed < 10*time.Millisecond {
+		t.Errorf("first call elapsed = %v, want at least the configured 10ms delay", elapsed)
+	}
+
+	start = time.Now()
+	out, err = mc.Commander("slow-tool").Output()
+	elapsed = time.Since(start)
+	if err != nil || string(out) != "second\n" {
+		t.Fatalf("second call Output() = (%q, %v), want (%q, nil)", out, err, "second\n")
+	}
+	if elapsed >= 10*time.Millisecond {
+		t.Errorf("second call elapsed = %v, want no delay", elapsed)
+	}
+}
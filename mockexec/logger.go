@@ -0,0 +1,25 @@
+package mockexec
+
+// Logger is the subset of *testing.T (or *testing.B) that diagnostic
+// logging needs. It lets helpers route their output through t.Logf, so it
+// appears attributed to the right test and only when that test fails or
+// -v is set, instead of fmt.Printf polluting every run and interleaving
+// badly under t.Parallel().
+type Logger interface {
+	Helper()
+	Logf(format string, args ...interface{})
+}
+
+// Quiet disables the diagnostic logging that NewMockShellCommanderForOutput
+// and its relatives emit for each invocation. Set it once (e.g. in
+// TestMain) to silence them across a whole package.
+var Quiet bool
+
+// logf reports a diagnostic via t.Logf, unless Quiet is set.
+func logf(t Logger, format string, args ...interface{}) {
+	if Quiet {
+		return
+	}
+	t.Helper()
+	t.Logf(format, args...)
+}
@@ -0,0 +1,42 @@
+package mockexec
+
+import "testing"
+
+type fakeLogger struct {
+	lines []string
+}
+
+func (f *fakeLogger) Helper() {}
+
+func (f *fakeLogger) Logf(format string, args ...interface{}) {
+	f.lines = append(f.lines, format)
+}
+
+func Test_NewMockShellCommanderForOutput_LogsByDefault(t *testing.T) {
+	fake := &fakeLogger{}
+	commander := NewMockShellCommanderForOutput("ok", nil, fake)
+
+	if _, err := commander("git", "status").Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	if len(fake.lines) == 0 {
+		t.Error("expected diagnostics to be logged, got none")
+	}
+}
+
+func Test_NewMockShellCommanderForOutput_QuietSuppressesLogs(t *testing.T) {
+	Quiet = true
+	defer func() { Quiet = false }()
+
+	fake := &fakeLogger{}
+	commander := NewMockShellCommanderForOutput("ok", nil, fake)
+
+	if _, err := commander("git", "status").Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	if len(fake.lines) != 0 {
+		t.Errorf("expected no diagnostics while Quiet, got %v", fake.lines)
+	}
+}
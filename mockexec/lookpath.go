@@ -0,0 +1,14 @@
+package mockexec
+
+import "os/exec"
+
+// LookPathFunc matches the signature of exec.LookPath, so application code
+// that checks whether a binary is on PATH before running it can depend on a
+// swappable package-level var the same way it depends on a
+// ShellCommanderFunc.
+type LookPathFunc func(file string) (string, error)
+
+// NewExecLookPath is the default, real LookPathFunc, backed by exec.LookPath.
+func NewExecLookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
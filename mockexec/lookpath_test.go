@@ -0,0 +1,25 @@
+package mockexec
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func Test_NewMockLookPath(t *testing.T) {
+	lookPath := NewMockLookPath("/usr/local/bin/git", nil)
+
+	path, err := lookPath("git")
+	if err != nil || path != "/usr/local/bin/git" {
+		t.Errorf("lookPath() = (%q, %v), want (%q, nil)", path, err, "/usr/local/bin/git")
+	}
+}
+
+func Test_NewMockLookPathNotFound(t *testing.T) {
+	lookPath := NewMockLookPathNotFound()
+
+	_, err := lookPath("git")
+	if !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("lookPath() error = %v, want it to wrap exec.ErrNotFound", err)
+	}
+}
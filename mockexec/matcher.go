@@ -0,0 +1,190 @@
+package mockexec
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ArgMatcher matches a single command-line argument. Pass one anywhere an
+// expectation takes an argument (e.g. MockCommander.ExpectCommand) to match
+// variable content like temp paths or timestamps instead of an exact string.
+type ArgMatcher interface {
+	Match(arg string) bool
+	String() string
+}
+
+type exactArg string
+
+func (e exactArg) Match(arg string) bool { return string(e) == arg }
+func (e exactArg) String() string        { return string(e) }
+
+// toArgMatcher wraps a plain string in an exact-match ArgMatcher, or passes
+// an already-constructed ArgMatcher through unchanged.
+func toArgMatcher(arg interface{}) ArgMatcher {
+	switch v := arg.(type) {
+	case ArgMatcher:
+		return v
+	case string:
+		return exactArg(v)
+	default:
+		panic(fmt.Sprintf("mockexec: unsupported expectation arg type %T", arg))
+	}
+}
+
+type anyArg struct{}
+
+// Any matches any single argument.
+func Any() ArgMatcher { return anyArg{} }
+
+func (anyArg) Match(string) bool { return true }
+func (anyArg) String() string    { return "<any>" }
+
+type regexArg struct{ re *regexp.Regexp }
+
+// Regexp matches an argument against the given regular expression.
+func Regexp(pattern string) ArgMatcher {
+	return regexArg{re: regexp.MustCompile(pattern)}
+}
+
+func (r regexArg) Match(arg string) bool { return r.re.MatchString(arg) }
+func (r regexArg) String() string        { return fmt.Sprintf("Regexp(%q)", r.re.String()) }
+
+type globArg struct{ pattern string }
+
+// Glob matches an argument against a shell-style glob pattern (filepath.Match rules).
+func Glob(pattern string) ArgMatcher { return globArg{pattern: pattern} }
+
+func (g globArg) Match(arg string) bool {
+	ok, err := filepath.Match(g.pattern, arg)
+	return err == nil && ok
+}
+func (g globArg) String() string { return fmt.Sprintf("Glob(%q)", g.pattern) }
+
+type prefixArg struct{ prefix string }
+
+// Prefix matches an argument that starts with the given prefix.
+func Prefix(prefix string) ArgMatcher { return prefixArg{prefix: prefix} }
+
+func (p prefixArg) Match(arg string) bool {
+	return len(arg) >= len(p.prefix) && arg[:len(p.prefix)] == p.prefix
+}
+func (p prefixArg) String() string { return fmt.Sprintf("Prefix(%q)", p.prefix) }
+
+// ArgsMatcher matches an entire invocation's argument list at once, for
+// rules that can't be expressed per-position - flag values regardless of
+// order or spacing, or "contains this subsequence somewhere" checks. Pass
+// one as ExpectCommand's only variadic argument (instead of a list of
+// per-position ArgMatchers/strings) to match this way.
+type ArgsMatcher interface {
+	MatchArgs(args []string) bool
+	String() string
+}
+
+type flagsArg struct{ want map[string]string }
+
+// Flags matches an invocation whose flags carry the given values,
+// regardless of flag order or whether each was written "--name=value",
+// "--name value", or "-name value" - so an expectation survives a wrapped
+// tool reordering its flags or switching between those forms across
+// versions. Positional (non-flag) arguments are ignored; a flag present
+// with no value (e.g. a trailing boolean flag) matches want[name] == "".
+func Flags(want map[string]string) ArgsMatcher {
+	return flagsArg{want: want}
+}
+
+func (f flagsArg) MatchArgs(args []string) bool {
+	got := parseFlags(args)
+	for name, value := range f.want {
+		if got[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (f flagsArg) String() string { return fmt.Sprintf("Flags(%v)", f.want) }
+
+type containsArgsArg struct{ subsequence []string }
+
+// ContainsArgs matches an invocation whose argument list contains
+// subsequence contiguously, in order, starting at any position - so an
+// expectation can pin down "--file foo.json" appearing somewhere without
+// caring what comes before or after it.
+func ContainsArgs(subsequence ...string) ArgsMatcher {
+	return containsArgsArg{subsequence: subsequence}
+}
+
+func (c containsArgsArg) MatchArgs(args []string) bool {
+	if len(c.subsequence) == 0 {
+		return true
+	}
+	for start := 0; start+len(c.subsequence) <= len(args); start++ {
+		match := true
+		for i, want := range c.subsequence {
+			if args[start+i] != want {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func (c containsArgsArg) String() string { return fmt.Sprintf("ContainsArgs(%v)", c.subsequence) }
+
+type containsAllArgsArg struct{ want []string }
+
+// ContainsAllArgs matches an invocation whose argument list includes every
+// element of want somewhere, in any order and any position relative to
+// each other or to the rest of the arguments - e.g. ContainsAllArgs("--json",
+// "deploy") matches "deploy --json --force" as readily as "--json deploy".
+func ContainsAllArgs(want ...string) ArgsMatcher {
+	return containsAllArgsArg{want: want}
+}
+
+func (c containsAllArgsArg) MatchArgs(args []string) bool {
+	present := make(map[string]bool, len(args))
+	for _, a := range args {
+		present[a] = true
+	}
+	for _, w := range c.want {
+		if !present[w] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c containsAllArgsArg) String() string { return fmt.Sprintf("ContainsAllArgs(%v)", c.want) }
+
+// parseFlags extracts flag/value pairs from an argument list. It accepts
+// "--name=value", "--name value", "-name=value", and "-name value" (the
+// leading dashes are stripped either way, so "-n" and "--n" are the same
+// key); a flag immediately followed by another flag, or at the end of the
+// list, is recorded with an empty value.
+func parseFlags(args []string) map[string]string {
+	got := make(map[string]string, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		if eq := strings.IndexByte(name, '='); eq >= 0 {
+			got[name[:eq]] = name[eq+1:]
+			continue
+		}
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			got[name] = args[i+1]
+			i++
+			continue
+		}
+		got[name] = ""
+	}
+	return got
+}
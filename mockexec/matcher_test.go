@@ -0,0 +1,86 @@
+package mockexec
+
+import "testing"
+
+func Test_MockCommander_ArgMatchers(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("cp", Any(), Glob("*.tar.gz")).Return("", nil)
+	mc.ExpectCommand("curl", Prefix("--file="), Regexp(`^https://.*\.json$`)).Return("", nil)
+
+	mc.Commander("cp", "/tmp/a", "backup.tar.gz")
+	mc.Commander("curl", "--file=x", "https://example.com/data.json")
+
+	mc.Verify(t)
+}
+
+func Test_Flags_MatchesRegardlessOfOrderAndSpacing(t *testing.T) {
+	m := Flags(map[string]string{"n": "5", "output": "json"})
+
+	cases := [][]string{
+		{"--n=5", "--output=json"},
+		{"--n", "5", "--output", "json"},
+		{"-n", "5", "--output=json"},
+		{"--output=json", "positional", "--n", "5"},
+	}
+	for _, args := range cases {
+		if !m.MatchArgs(args) {
+			t.Errorf("Flags(...).MatchArgs(%v) = false, want true", args)
+		}
+	}
+
+	if m.MatchArgs([]string{"--n=6", "--output=json"}) {
+		t.Error("Flags(...).MatchArgs with wrong value = true, want false")
+	}
+}
+
+func Test_MockCommander_ExpectCommand_WithFlags(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("kubectl", Flags(map[string]string{"n": "kube-system"})).Return("pod-1\n", nil)
+
+	out, err := mc.Commander("kubectl", "get", "pods", "-n", "kube-system", "-o", "json").Output()
+	if err != nil || string(out) != "pod-1\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "pod-1\n")
+	}
+
+	mc.Verify(t)
+}
+
+func Test_ContainsArgs_MatchesContiguousSubsequenceAnywhere(t *testing.T) {
+	m := ContainsArgs("--file", "foo.json")
+
+	if !m.MatchArgs([]string{"upload", "--file", "foo.json", "--force"}) {
+		t.Error("ContainsArgs(...).MatchArgs = false, want true")
+	}
+	if m.MatchArgs([]string{"--file", "--force", "foo.json"}) {
+		t.Error("ContainsArgs(...).MatchArgs with args out of order = true, want false")
+	}
+	if m.MatchArgs([]string{"upload"}) {
+		t.Error("ContainsArgs(...).MatchArgs with missing subsequence = true, want false")
+	}
+}
+
+func Test_ContainsAllArgs_MatchesRegardlessOfOrder(t *testing.T) {
+	m := ContainsAllArgs("--json", "deploy")
+
+	if !m.MatchArgs([]string{"deploy", "--json", "--force"}) {
+		t.Error("ContainsAllArgs(...).MatchArgs = false, want true")
+	}
+	if !m.MatchArgs([]string{"--json", "--force", "deploy"}) {
+		t.Error("ContainsAllArgs(...).MatchArgs (reordered) = false, want true")
+	}
+	if m.MatchArgs([]string{"deploy", "--force"}) {
+		t.Error("ContainsAllArgs(...).MatchArgs missing --json = true, want false")
+	}
+}
+
+func Test_MockCommander_ExpectCommand_WithContainsAllArgs(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("tool", ContainsAllArgs("--json", "deploy")).Return("ok\n", nil)
+
+	out, err := mc.Commander("tool", "deploy", "--env=prod", "--json").Output()
+	if err != nil || string(out) != "ok\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "ok\n")
+	}
+
+	mc.Verify(t)
+}
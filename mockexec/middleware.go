@@ -0,0 +1,18 @@
+package mockexec
+
+// Middleware wraps a ShellCommanderFunc with another, so cross-cutting
+// concerns - logging, metrics, call recording, arg rewriting - can be
+// layered onto any commander (real or mock) without changing call sites.
+// Apply it with Chain.
+type Middleware func(next ShellCommanderFunc) ShellCommanderFunc
+
+// Chain wraps base with the given middlewares, applied in the order given:
+// Chain(base, a, b) runs a's logic, then b's, then base, so a sees the
+// invocation first and is outermost.
+func Chain(base ShellCommanderFunc, middlewares ...Middleware) ShellCommanderFunc {
+	wrapped := base
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		wrapped = middlewares[i](wrapped)
+	}
+	return wrapped
+}
@@ -0,0 +1,38 @@
+package mockexec
+
+import "testing"
+
+func Test_Chain_AppliesMiddlewareInOrder(t *testing.T) {
+	var order []string
+
+	record := func(label string) Middleware {
+		return func(next ShellCommanderFunc) ShellCommanderFunc {
+			return func(name string, arg ...string) IShellCommand {
+				order = append(order, label)
+				return next(name, arg...)
+			}
+		}
+	}
+
+	base := func(name string, arg ...string) IShellCommand {
+		order = append(order, "base")
+		return &MockShellCommand{Stdout: []byte("ok\n")}
+	}
+
+	commander := Chain(base, record("a"), record("b"))
+
+	out, err := commander("git", "status").Output()
+	if err != nil || string(out) != "ok\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "ok\n")
+	}
+
+	want := []string{"a", "b", "base"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("order[%d] = %q, want %q", i, order[i], want[i])
+		}
+	}
+}
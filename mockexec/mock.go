@@ -0,0 +1,571 @@
+package mockexec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// fakePidCounter hands out deterministic, monotonically increasing fake
+// PIDs to MockShellCommands that don't set PidValue explicitly, so
+// supervisor code that stores PIDs for later signaling sees distinct
+// values per command without colliding with real process IDs.
+var fakePidCounter int64 = 10000
+
+// MockShellCommand is an IShellCommand test double. Construct it as a
+// literal with the funcs/fields relevant to the behavior under test; unset
+// fields fall back to sensible defaults (e.g. CombinedOutput falls back to
+// Output, Run falls back to Output).
+type MockShellCommand struct {
+	OutputterFunc         func() ([]byte, error)
+	CombinedOutputterFunc func() ([]byte, error)
+	RunnerFunc            func() error
+	WaiterFunc            func() error
+
+	// Stdout/Stderr/Err are the literal-response alternative to
+	// OutputterFunc: when OutputterFunc is nil, Output/CombinedOutput/Run
+	// serve these directly, which is enough for the common case of "this
+	// command produces this stdout, this stderr, and this error" without
+	// writing a closure. Stderr is surfaced through CombinedOutput, Run's
+	// configured stderr writer, and StderrPipe, but never through Output,
+	// matching exec.Cmd.
+	Stdout []byte
+	Stderr []byte
+	Err    error
+
+	// LookupErrValue, if set, mirrors exec.Cmd.Err (Go 1.19): it's recorded
+	// at construction time but only surfaces once Start/Run/Output/
+	// CombinedOutput is actually called, instead of immediately, so a test
+	// can script "this binary isn't on PATH" and still exercise code that
+	// calls SetDir/SetEnv/etc. on the command before running it.
+	LookupErrValue error
+
+	// Delay, if set, is how long Output/CombinedOutput/Run sleeps before
+	// producing its response, e.g. to test timeout budgets or "slow
+	// command" warnings.
+	Delay time.Duration
+
+	StdoutChunks []string
+	StderrChunks []string
+
+	// StdoutChunkDelays/StderrChunkDelays, if set, is how long StdoutPipe/
+	// StderrPipe sleeps before delivering the chunk at the matching index,
+	// e.g. to test line-by-line processing or read-deadline handling
+	// deterministically. A shorter slice than the chunks leaves the
+	// remaining chunks undelayed.
+	StdoutChunkDelays []time.Duration
+	StderrChunkDelays []time.Duration
+
+	ExitCodeValue int
+
+	// TTY declares whether this command should behave as if attached to a
+	// terminal, surfaced via IsTTY (see TTYAware). It has no effect on any
+	// other behavior - StdoutChunks/Dialogue/etc. are unaffected - it's
+	// purely what code checking TTYAware observes, for covering both the
+	// TTY and non-TTY branches of output parsing.
+	TTY bool
+
+	// PidValue, if set, is what Pid returns. Left unset, Pid assigns (and
+	// thereafter returns) a deterministic fake PID the first time it's
+	// called.
+	PidValue int
+
+	// ProcessStateValue, if set, is what ProcessState returns, e.g. a
+	// FakeProcessState configured with the exit code and CPU times a test
+	// wants Wait's caller to observe. Left nil, ProcessState returns nil,
+	// matching a command that hasn't been waited on yet.
+	ProcessStateValue ProcessState
+
+	// KilledWaitErr, if set, is what Wait returns once Kill or Signal has
+	// been called, emulating a process that unblocks Wait() after a signal.
+	KilledWaitErr error
+
+	// SignalFunc, if set, overrides Signal's (and so Kill's, which is
+	// Signal(os.Kill)) default behavior of unconditionally recording the
+	// signal and marking the command killed, so a test can script a
+	// per-signal scenario - e.g. NewGracefulShutdownCommand's SIGTERM-then-
+	// SIGKILL escalation.
+	SignalFunc func(os.Signal) error
+
+	// BlockUntil, if set, makes Output/Run/Wait block until the channel is
+	// closed/signaled, or until the mock's context (see shellCommanderContext)
+	// is done, whichever happens first. This emulates a command that hangs,
+	// for testing watchdogs and timeout handling.
+	BlockUntil <-chan struct{}
+
+	// Dialogue, if set, scripts an interactive back-and-forth over
+	// StdoutPipe/StdinPipe instead of a one-shot response: each step's
+	// Output is streamed to the StdoutPipe reader in turn, and a step with
+	// WaitForLine pauses streaming until a full line has been written to
+	// the StdinPipe writer. This lets a test drive a wrapper around an
+	// interactive command - e.g. "Are you sure? [y/N]", wait for an
+	// answer, then a final status line - without a real TTY.
+	Dialogue []DialogueStep
+
+	dialogueOnce    sync.Once
+	dialogueStdoutR *io.PipeReader
+	dialogueStdoutW *io.PipeWriter
+	dialogueStdinW  *io.PipeWriter
+
+	ctx    context.Context
+	dir    string
+	env    []string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	cancel      func() error
+	waitDelay   time.Duration
+	sysProcAttr *syscall.SysProcAttr
+	extraFiles  []*os.File
+
+	started      bool
+	waited       bool
+	killed       bool
+	cancelCalled bool
+	lastSignal   os.Signal
+	stdinBuf     bytes.Buffer
+	pid          int
+}
+
+// CapturedStdin returns everything written through the pipe returned by StdinPipe.
+func (sc *MockShellCommand) CapturedStdin() string {
+	return sc.stdinBuf.String()
+}
+
+// LastSignal returns the signal last delivered via Signal, or via Kill (os.Kill).
+func (sc *MockShellCommand) LastSignal() os.Signal {
+	return sc.lastSignal
+}
+
+// StartedWithoutWait reports whether Start was called on this command but
+// Wait never followed, the same shape a leaked goroutine/zombie process
+// takes in production when code forgets to call cmd.Wait() after
+// cmd.Start(). MockCommander's Verify checks this for every command an
+// expectation handed out.
+func (sc *MockShellCommand) StartedWithoutWait() bool {
+	return sc.started && !sc.waited
+}
+
+// IsTTY reports the TTY field, satisfying TTYAware so a mock can stand in
+// for PTYShellCommand's "always a terminal" or the default non-TTY pipe
+// behavior, as the test requires.
+func (sc *MockShellCommand) IsTTY() bool {
+	return sc.TTY
+}
+
+// awaitUnblocked waits for BlockUntil (if set) and returns the context's
+// error (if any) should the context finish first, after simulating the
+// Cancel/WaitDelay escalation SetCancel/SetWaitDelay configured.
+func (sc *MockShellCommand) awaitUnblocked() error {
+	if sc.BlockUntil == nil {
+		if sc.ctx != nil && sc.ctx.Err() != nil {
+			sc.escalateCancel()
+			return sc.ctx.Err()
+		}
+		return nil
+	}
+	if sc.ctx == nil {
+		<-sc.BlockUntil
+		return nil
+	}
+	select {
+	case <-sc.BlockUntil:
+		return nil
+	case <-sc.ctx.Done():
+		sc.escalateCancel()
+		return sc.ctx.Err()
+	}
+}
+
+// escalateCancel mimics what exec.Cmd does once its context is done: call
+// Cancel (if set via SetCancel), give the process WaitDelay to exit on its
+// own, then Kill it.
+func (sc *MockShellCommand) escalateCancel() {
+	if sc.cancel != nil {
+		sc.cancelCalled = true
+		sc.cancel()
+	}
+	if sc.waitDelay > 0 {
+		time.Sleep(sc.waitDelay)
+	}
+	sc.Kill()
+}
+
+// LookupErr returns LookupErrValue, mirroring exec.Cmd.Err.
+func (sc *MockShellCommand) LookupErr() error {
+	return sc.LookupErrValue
+}
+
+// Output mimics exec.Cmd.Output's own misuse checks: it refuses to run a
+// command that's already been started (Start, Run, or a prior Output/
+// CombinedOutput), and refuses to run if SetStdout already installed a
+// writer, since Output needs that slot for its own buffer.
+func (sc *MockShellCommand) Output() ([]byte, error) {
+	if sc.LookupErrValue != nil {
+		return nil, sc.LookupErrValue
+	}
+	if sc.started {
+		return nil, errors.New("exec: already started")
+	}
+	if sc.stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+	sc.started = true
+	defer func() { sc.waited = true }()
+	if err := sc.awaitUnblocked(); err != nil {
+		return nil, err
+	}
+	if sc.Delay > 0 {
+		time.Sleep(sc.Delay)
+	}
+	if sc.OutputterFunc != nil {
+		return sc.OutputterFunc()
+	}
+	return sc.Stdout, sc.Err
+}
+
+// CombinedOutput mimics exec.Cmd.CombinedOutput's own misuse checks: same
+// as Output, plus SetStderr must not have installed a writer either, since
+// CombinedOutput needs both slots for its own buffer.
+func (sc *MockShellCommand) CombinedOutput() ([]byte, error) {
+	if sc.LookupErrValue != nil {
+		return nil, sc.LookupErrValue
+	}
+	if sc.started {
+		return nil, errors.New("exec: already started")
+	}
+	if sc.stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+	if sc.stderr != nil {
+		return nil, errors.New("exec: Stderr already set")
+	}
+	sc.started = true
+	defer func() { sc.waited = true }()
+	if sc.Delay > 0 {
+		time.Sleep(sc.Delay)
+	}
+	if sc.CombinedOutputterFunc != nil {
+		return sc.CombinedOutputterFunc()
+	}
+	if sc.OutputterFunc != nil {
+		return sc.OutputterFunc()
+	}
+	return append(append([]byte{}, sc.Stdout...), sc.Stderr...), sc.Err
+}
+
+// Run mimics exec.Cmd.Run's own misuse check: it refuses to run a command
+// that's already been started, the same as Start does, since Run is just
+// Start followed by Wait.
+func (sc *MockShellCommand) Run() error {
+	if sc.LookupErrValue != nil {
+		return sc.LookupErrValue
+	}
+	if sc.started {
+		return errors.New("exec: already started")
+	}
+	sc.started = true
+	defer func() { sc.waited = true }()
+	if err := sc.awaitUnblocked(); err != nil {
+		return err
+	}
+	if sc.Delay > 0 {
+		time.Sleep(sc.Delay)
+	}
+	if sc.RunnerFunc != nil {
+		return sc.RunnerFunc()
+	}
+	if sc.OutputterFunc != nil {
+		out, err := sc.OutputterFunc()
+		if sc.stdout != nil {
+			sc.stdout.Write(out)
+		}
+		return err
+	}
+	if sc.stdout != nil {
+		sc.stdout.Write(sc.Stdout)
+	}
+	if sc.stderr != nil {
+		sc.stderr.Write(sc.Stderr)
+	}
+	return sc.Err
+}
+
+// Dir returns the directory last set via SetDir.
+func (sc *MockShellCommand) Dir() string {
+	return sc.dir
+}
+
+func (sc *MockShellCommand) SetDir(dir string) {
+	sc.dir = dir
+}
+
+func (sc *MockShellCommand) SetEnv(env []string) {
+	sc.env = env
+}
+
+func (sc *MockShellCommand) Environ() []string {
+	return sc.env
+}
+
+// SetStdin stores r and, if non-nil, eagerly drains it into the same
+// buffer StdinPipe writes land in, so CapturedStdin (and WithStdinJSON)
+// see content set either way - a real *exec.Cmd only reads Stdin once the
+// process starts, but the mock has no process to defer to.
+func (sc *MockShellCommand) SetStdin(r io.Reader) {
+	sc.stdin = r
+	if r != nil {
+		io.Copy(&sc.stdinBuf, r)
+	}
+}
+
+func (sc *MockShellCommand) SetStdout(w io.Writer) {
+	sc.stdout = w
+}
+
+func (sc *MockShellCommand) SetStderr(w io.Writer) {
+	sc.stderr = w
+}
+
+// ExitCode returns the scripted ExitCodeValue, so a test can pair a given
+// exit code with the error it configured the mock to produce.
+func (sc *MockShellCommand) ExitCode() int {
+	return sc.ExitCodeValue
+}
+
+func (sc *MockShellCommand) Kill() error {
+	return sc.Signal(os.Kill)
+}
+
+// Signal records sig and marks the command killed, unless SignalFunc is
+// set, in which case sig is routed there instead - e.g. to script a
+// graceful-shutdown scenario where SIGTERM and SIGKILL behave differently.
+// See NewGracefulShutdownCommand.
+func (sc *MockShellCommand) Signal(sig os.Signal) error {
+	if sc.SignalFunc != nil {
+		return sc.SignalFunc(sig)
+	}
+	sc.killed = true
+	sc.lastSignal = sig
+	return nil
+}
+
+// Pid returns PidValue if set, or else a deterministic fake PID assigned
+// the first time Pid is called.
+func (sc *MockShellCommand) Pid() int {
+	if sc.PidValue != 0 {
+		return sc.PidValue
+	}
+	if sc.pid == 0 {
+		sc.pid = int(atomic.AddInt64(&fakePidCounter, 1))
+	}
+	return sc.pid
+}
+
+// ProcessState returns ProcessStateValue, so a test can assert on e.g.
+// Success() or UserTime() after Wait without needing a real process.
+func (sc *MockShellCommand) ProcessState() ProcessState {
+	return sc.ProcessStateValue
+}
+
+func (sc *MockShellCommand) SetCancel(cancel func() error) {
+	sc.cancel = cancel
+}
+
+func (sc *MockShellCommand) SetWaitDelay(d time.Duration) {
+	sc.waitDelay = d
+}
+
+// CancelCalled reports whether the function set via SetCancel has been
+// invoked, e.g. because the mock's context (see shellCommanderContext) was
+// canceled before the command finished.
+func (sc *MockShellCommand) CancelCalled() bool {
+	return sc.cancelCalled
+}
+
+func (sc *MockShellCommand) SetSysProcAttr(attr *syscall.SysProcAttr) {
+	sc.sysProcAttr = attr
+}
+
+// SysProcAttr returns the value last set via SetSysProcAttr, so a test can
+// assert on e.g. Setpgid without the code under test needing to expose it
+// separately.
+func (sc *MockShellCommand) SysProcAttr() *syscall.SysProcAttr {
+	return sc.sysProcAttr
+}
+
+func (sc *MockShellCommand) SetExtraFiles(files []*os.File) {
+	sc.extraFiles = files
+}
+
+// ExtraFiles returns the files last set via SetExtraFiles, in descriptor
+// order.
+func (sc *MockShellCommand) ExtraFiles() []*os.File {
+	return sc.extraFiles
+}
+
+// ExtraFileNames returns Name() of each file set via SetExtraFiles, for
+// tests that only care which files were passed through, not their
+// *os.File identity.
+func (sc *MockShellCommand) ExtraFileNames() []string {
+	names := make([]string, len(sc.extraFiles))
+	for i, f := range sc.extraFiles {
+		if f != nil {
+			names[i] = f.Name()
+		}
+	}
+	return names
+}
+
+func (sc *MockShellCommand) StdoutPipe() (io.ReadCloser, error) {
+	if sc.Dialogue != nil {
+		sc.startDialogue()
+		return sc.dialogueStdoutR, nil
+	}
+	chunks := sc.StdoutChunks
+	if chunks == nil && sc.Stdout != nil {
+		chunks = []string{string(sc.Stdout)}
+	}
+	return newChunkedReadCloser(chunks, sc.StdoutChunkDelays), nil
+}
+
+func (sc *MockShellCommand) StderrPipe() (io.ReadCloser, error) {
+	chunks := sc.StderrChunks
+	if chunks == nil && sc.Stderr != nil {
+		chunks = []string{string(sc.Stderr)}
+	}
+	return newChunkedReadCloser(chunks, sc.StderrChunkDelays), nil
+}
+
+func (sc *MockShellCommand) StdinPipe() (io.WriteCloser, error) {
+	if sc.Dialogue != nil {
+		sc.startDialogue()
+		return &dialogueStdinWriter{pipe: sc.dialogueStdinW, buf: &sc.stdinBuf}, nil
+	}
+	return nopWriteCloser{&sc.stdinBuf}, nil
+}
+
+// DialogueStep is one exchange in a MockShellCommand.Dialogue; see its
+// doc comment.
+type DialogueStep struct {
+	Output      string
+	WaitForLine bool
+}
+
+// startDialogue lazily wires up the pipes backing StdoutPipe/StdinPipe and
+// starts the goroutine that streams each Dialogue step's Output, pausing
+// on a WaitForLine step until a line has been read from the stdin pipe.
+func (sc *MockShellCommand) startDialogue() {
+	sc.dialogueOnce.Do(func() {
+		var stdinR *io.PipeReader
+		sc.dialogueStdoutR, sc.dialogueStdoutW = io.Pipe()
+		stdinR, sc.dialogueStdinW = io.Pipe()
+
+		go func() {
+			lines := bufio.NewReader(stdinR)
+			for _, step := range sc.Dialogue {
+				io.WriteString(sc.dialogueStdoutW, step.Output)
+				if step.WaitForLine {
+					lines.ReadString('\n')
+				}
+			}
+			sc.dialogueStdoutW.Close()
+		}()
+	})
+}
+
+// dialogueStdinWriter forwards writes to the dialogue's stdin pipe while
+// also capturing them into buf, so CapturedStdin reflects an interactive
+// session's answers the same way it does a non-interactive StdinPipe write.
+type dialogueStdinWriter struct {
+	pipe *io.PipeWriter
+	buf  *bytes.Buffer
+}
+
+func (w *dialogueStdinWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	return w.pipe.Write(p)
+}
+
+func (w *dialogueStdinWriter) Close() error {
+	return w.pipe.Close()
+}
+
+// Start mimics exec.Cmd: it may not be called more than once.
+func (sc *MockShellCommand) Start() error {
+	if sc.LookupErrValue != nil {
+		return sc.LookupErrValue
+	}
+	if sc.started {
+		return errors.New("exec: already started")
+	}
+	sc.started = true
+	return nil
+}
+
+// Wait mimics exec.Cmd: it requires a prior Start and may not be called more than once.
+func (sc *MockShellCommand) Wait() error {
+	if err := sc.awaitUnblocked(); err != nil {
+		return err
+	}
+	if !sc.started {
+		return errors.New("exec: not started")
+	}
+	if sc.waited {
+		return errors.New("exec: Wait was already called")
+	}
+	sc.waited = true
+	if sc.killed {
+		return sc.KilledWaitErr
+	}
+	if sc.WaiterFunc == nil {
+		return nil
+	}
+	return sc.WaiterFunc()
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// chunkedReadCloser streams a sequence of chunks, one Read() at a time, to
+// emulate a pipe that delivers output incrementally rather than all at once.
+type chunkedReadCloser struct {
+	chunks []string
+	delays []time.Duration
+	pos    int
+	// off is how much of chunks[pos] has already been copied out by a
+	// previous Read, for when the caller's buffer is smaller than the
+	// chunk; pos only advances once off reaches the end of the chunk.
+	off int
+}
+
+func (c *chunkedReadCloser) Read(p []byte) (int, error) {
+	if c.pos >= len(c.chunks) {
+		return 0, io.EOF
+	}
+	if c.off == 0 && c.pos < len(c.delays) {
+		time.Sleep(c.delays[c.pos])
+	}
+	n := copy(p, c.chunks[c.pos][c.off:])
+	c.off += n
+	if c.off >= len(c.chunks[c.pos]) {
+		c.pos++
+		c.off = 0
+	}
+	return n, nil
+}
+
+func (c *chunkedReadCloser) Close() error { return nil }
+
+func newChunkedReadCloser(chunks []string, delays []time.Duration) io.ReadCloser {
+	return &chunkedReadCloser{chunks: chunks, delays: delays}
+}
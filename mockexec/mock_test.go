@@ -0,0 +1,262 @@
+package mockexec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_MockShellCommand_StdoutPipe(t *testing.T) {
+	cmd := &MockShellCommand{StdoutChunks: []string{"line1\n", "line2\n"}}
+
+	pipe, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("StdoutPipe() failed: %v", err)
+	}
+	defer pipe.Close()
+
+	buf := make([]byte, 0)
+	chunk := make([]byte, 64)
+	for {
+		n, readErr := pipe.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	got := string(buf)
+	want := "line1\nline2\n"
+	if !strings.HasPrefix(got, "line1") || got != want {
+		t.Errorf("StdoutPipe() streamed %q, want %q", got, want)
+	}
+}
+
+func Test_MockShellCommand_StdoutPipe_SmallBufferReadsFullChunk(t *testing.T) {
+	cmd := &MockShellCommand{StdoutChunks: []string{strings.Repeat("x", 2000)}}
+
+	pipe, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("StdoutPipe() failed: %v", err)
+	}
+	defer pipe.Close()
+
+	got, err := io.ReadAll(&smallChunkReader{pipe, 100})
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(got) != 2000 {
+		t.Errorf("ReadAll() through a 100-byte buffer got %d bytes, want 2000", len(got))
+	}
+}
+
+// smallChunkReader caps every underlying Read at max bytes, to exercise a
+// caller whose buffer is smaller than a chunk written via StdoutChunks.
+type smallChunkReader struct {
+	r   io.Reader
+	max int
+}
+
+func (s *smallChunkReader) Read(p []byte) (int, error) {
+	if len(p) > s.max {
+		p = p[:s.max]
+	}
+	return s.r.Read(p)
+}
+
+func Test_MockShellCommand_StdinPipe(t *testing.T) {
+	cmd := &MockShellCommand{}
+
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatalf("StdinPipe() failed: %v", err)
+	}
+	if _, err := in.Write([]byte("hello stdin")); err != nil {
+		t.Fatalf("write to stdin pipe failed: %v", err)
+	}
+	in.Close()
+
+	if got, want := cmd.CapturedStdin(), "hello stdin"; got != want {
+		t.Errorf("CapturedStdin() = %q, want %q", got, want)
+	}
+}
+
+func Test_MockShellCommand_SetEnvAndEnviron(t *testing.T) {
+	cmd := &MockShellCommand{}
+	cmd.SetEnv([]string{"GIT_DIR=/repo/.git", "AWS_PROFILE=prod"})
+
+	env := cmd.Environ()
+	if len(env) != 2 || env[0] != "GIT_DIR=/repo/.git" || env[1] != "AWS_PROFILE=prod" {
+		t.Errorf("Environ() = %v, want the env passed to SetEnv", env)
+	}
+}
+
+func Test_MockShellCommand_HonorsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	commander := NewMockShellCommanderContextForOutput("hello", nil)
+
+	if _, err := commander(ctx, "git", "status").Output(); err != nil {
+		t.Fatalf("Output() before cancel failed: %v", err)
+	}
+
+	cancel()
+	if _, err := commander(ctx, "git", "status").Output(); err != ctx.Err() {
+		t.Errorf("Output() after cancel = %v, want %v", err, ctx.Err())
+	}
+}
+
+func Test_MockShellCommand_ExitCode(t *testing.T) {
+	cmd := &MockShellCommand{
+		OutputterFunc: func() ([]byte, error) { return nil, errors.New("exit status 1") },
+		ExitCodeValue: 1,
+	}
+	if _, err := cmd.Output(); err == nil {
+		t.Fatal("expected Output() to fail")
+	}
+	if got := cmd.ExitCode(); got != 1 {
+		t.Errorf("ExitCode() = %v, want 1", got)
+	}
+}
+
+func Test_MockShellCommand_KillUnblocksWait(t *testing.T) {
+	cmd := &MockShellCommand{KilledWaitErr: errors.New("signal: killed")}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+
+	if err := cmd.Kill(); err != nil {
+		t.Fatalf("Kill() failed: %v", err)
+	}
+	if err := cmd.Wait(); err == nil || err.Error() != "signal: killed" {
+		t.Errorf("Wait() after Kill() = %v, want %q", err, "signal: killed")
+	}
+}
+
+func Test_MockShellCommand_SetStdout(t *testing.T) {
+	cmd := &MockShellCommand{OutputterFunc: func() ([]byte, error) { return []byte("hi"), nil }}
+	var buf bytes.Buffer
+	cmd.SetStdout(&buf)
+
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if got := buf.String(); got != "hi" {
+		t.Errorf("stdout buffer = %q, want %q", got, "hi")
+	}
+}
+
+func Test_MockShellCommand_StartWaitStateMachine(t *testing.T) {
+	cmd := &MockShellCommand{WaiterFunc: func() error { return nil }}
+
+	if err := cmd.Wait(); err == nil {
+		t.Error("expected Wait before Start to fail")
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	if err := cmd.Start(); err == nil {
+		t.Error("expected second Start to fail")
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("Wait() failed: %v", err)
+	}
+	if err := cmd.Wait(); err == nil {
+		t.Error("expected second Wait to fail")
+	}
+}
+
+func Test_MockShellCommand_LookupErrValue_DeferredUntilRunCalled(t *testing.T) {
+	wantErr := errors.New("exec: \"nosuchbinary\": executable file not found in $PATH")
+	cmd := &MockShellCommand{LookupErrValue: wantErr}
+
+	// Setters before Run/Start/Output still work, matching a real *exec.Cmd
+	// whose Err is only consulted once the command actually runs.
+	cmd.SetDir("/tmp")
+	cmd.SetEnv([]string{"FOO=bar"})
+
+	if got := cmd.LookupErr(); got != wantErr {
+		t.Errorf("LookupErr() = %v, want %v", got, wantErr)
+	}
+
+	if _, err := cmd.Output(); err != wantErr {
+		t.Errorf("Output() err = %v, want %v", err, wantErr)
+	}
+	if _, err := cmd.CombinedOutput(); err != wantErr {
+		t.Errorf("CombinedOutput() err = %v, want %v", err, wantErr)
+	}
+	if err := cmd.Run(); err != wantErr {
+		t.Errorf("Run() err = %v, want %v", err, wantErr)
+	}
+	if err := cmd.Start(); err != wantErr {
+		t.Errorf("Start() err = %v, want %v", err, wantErr)
+	}
+}
+
+func Test_MockShellCommand_OutputAfterStart_ErrorsLikeRealExec(t *testing.T) {
+	cmd := &MockShellCommand{Stdout: []byte("hello\n")}
+
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	if _, err := cmd.Output(); err == nil || err.Error() != "exec: already started" {
+		t.Errorf("Output() after Start() = %v, want %q", err, "exec: already started")
+	}
+}
+
+func Test_MockShellCommand_RunAfterStart_ErrorsLikeRealExec(t *testing.T) {
+	cmd := &MockShellCommand{}
+
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	if err := cmd.Run(); err == nil || err.Error() != "exec: already started" {
+		t.Errorf("Run() after Start() = %v, want %q", err, "exec: already started")
+	}
+}
+
+func Test_MockShellCommand_OutputAfterSetStdout_ErrorsLikeRealExec(t *testing.T) {
+	cmd := &MockShellCommand{Stdout: []byte("hello\n")}
+	cmd.SetStdout(&bytes.Buffer{})
+
+	if _, err := cmd.Output(); err == nil || err.Error() != "exec: Stdout already set" {
+		t.Errorf("Output() after SetStdout = %v, want %q", err, "exec: Stdout already set")
+	}
+}
+
+func Test_MockShellCommand_CombinedOutputAfterSetStderr_ErrorsLikeRealExec(t *testing.T) {
+	cmd := &MockShellCommand{Stdout: []byte("hello\n")}
+	cmd.SetStderr(&bytes.Buffer{})
+
+	if _, err := cmd.CombinedOutput(); err == nil || err.Error() != "exec: Stderr already set" {
+		t.Errorf("CombinedOutput() after SetStderr = %v, want %q", err, "exec: Stderr already set")
+	}
+}
+
+func Test_MockShellCommand_ReuseAfterFinishedRun_ErrorsLikeRealExec(t *testing.T) {
+	cmd := &MockShellCommand{Stdout: []byte("hello\n")}
+
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+	if err := cmd.Start(); err == nil || err.Error() != "exec: already started" {
+		t.Errorf("Start() on a finished command = %v, want %q", err, "exec: already started")
+	}
+	if _, err := cmd.Output(); err == nil || err.Error() != "exec: already started" {
+		t.Errorf("second Output() on a finished command = %v, want %q", err, "exec: already started")
+	}
+}
+
+func Test_ExecShellCommand_LookupErr_ReflectsRealLookPathFailure(t *testing.T) {
+	cmd := NewExecShellCommander("nosuchbinary-mockexec-test")
+
+	if err := cmd.LookupErr(); err == nil {
+		t.Error("LookupErr() = nil, want the LookPath failure recorded by exec.Command")
+	}
+
+	if _, err := cmd.Output(); err == nil {
+		t.Error("Output() = nil error, want it to surface the same lookup failure")
+	}
+}
@@ -0,0 +1,68 @@
+package mockexec
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"time"
+)
+
+// NopCommander is a ShellCommanderFunc provider whose Commander method
+// hands back the same pre-allocated IShellCommand on every call instead of
+// constructing a fresh one, so a benchmark of exec-heavy code measures the
+// caller's own overhead instead of the mock's output formatting and slice
+// allocations. It has no use for asserting behavior - every call ignores
+// name and args and returns the same canned response - so use MockCommander
+// or NewMockShellCommanderForOutput instead wherever a test needs to
+// distinguish calls.
+type NopCommander struct {
+	cmd nopShellCommand
+}
+
+// NewNopCommander returns a NopCommander whose commands all return output
+// and err, unconditionally.
+func NewNopCommander(output []byte, err error) *NopCommander {
+	return &NopCommander{cmd: nopShellCommand{output: output, err: err}}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander
+// var for benchmarking. It ignores name and args and returns the same
+// IShellCommand on every call.
+func (nc *NopCommander) Commander(name string, args ...string) IShellCommand {
+	return &nc.cmd
+}
+
+// nopShellCommand is an IShellCommand whose setters are all no-ops and
+// whose output-producing methods return a fixed, pre-allocated response. A
+// single instance is shared across every call a NopCommander hands out.
+type nopShellCommand struct {
+	output []byte
+	err    error
+}
+
+func (n *nopShellCommand) SetDir(string)                       {}
+func (n *nopShellCommand) SetEnv([]string)                     {}
+func (n *nopShellCommand) Environ() []string                   { return nil }
+func (n *nopShellCommand) SetStdin(io.Reader)                  {}
+func (n *nopShellCommand) SetStdout(io.Writer)                 {}
+func (n *nopShellCommand) SetStderr(io.Writer)                 {}
+func (n *nopShellCommand) ExitCode() int                       { return 0 }
+func (n *nopShellCommand) Kill() error                         { return nil }
+func (n *nopShellCommand) Signal(os.Signal) error              { return nil }
+func (n *nopShellCommand) Pid() int                            { return 0 }
+func (n *nopShellCommand) ProcessState() ProcessState          { return nil }
+func (n *nopShellCommand) SetCancel(func() error)              {}
+func (n *nopShellCommand) SetWaitDelay(time.Duration)          {}
+func (n *nopShellCommand) SetSysProcAttr(*syscall.SysProcAttr) {}
+func (n *nopShellCommand) SetExtraFiles([]*os.File)            {}
+func (n *nopShellCommand) LookupErr() error                    { return nil }
+
+func (n *nopShellCommand) Output() ([]byte, error)         { return n.output, n.err }
+func (n *nopShellCommand) CombinedOutput() ([]byte, error) { return n.output, n.err }
+func (n *nopShellCommand) Run() error                      { return n.err }
+func (n *nopShellCommand) Start() error                    { return n.err }
+func (n *nopShellCommand) Wait() error                     { return n.err }
+
+func (n *nopShellCommand) StdoutPipe() (io.ReadCloser, error) { return nil, nil }
+func (n *nopShellCommand) StderrPipe() (io.ReadCloser, error) { return nil, nil }
+func (n *nopShellCommand) StdinPipe() (io.WriteCloser, error) { return nil, nil }
@@ -0,0 +1,37 @@
+package mockexec
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_NopCommander_ReturnsConfiguredResponse(t *testing.T) {
+	wantErr := errors.New("boom")
+	nc := NewNopCommander([]byte("canned"), wantErr)
+
+	out, err := nc.Commander("anything", "ignored", "args").Output()
+	if string(out) != "canned" || err != wantErr {
+		t.Errorf("Output() = (%q, %v), want (%q, %v)", out, err, "canned", wantErr)
+	}
+}
+
+func Test_NopCommander_SameCommandEveryCall(t *testing.T) {
+	nc := NewNopCommander([]byte("ok"), nil)
+
+	a := nc.Commander("git", "status")
+	b := nc.Commander("docker", "ps")
+	if a != b {
+		t.Error("Commander() returned distinct IShellCommands, want the same one reused across calls")
+	}
+}
+
+func Benchmark_NopCommander_Output(b *testing.B) {
+	nc := NewNopCommander([]byte("ok"), nil)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := nc.Commander("git", "status").Output(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
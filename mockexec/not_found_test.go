@@ -0,0 +1,16 @@
+package mockexec
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func Test_NewMockShellCommanderNotFound(t *testing.T) {
+	commander := NewMockShellCommanderNotFound()
+
+	_, err := commander("terraform", "apply").Output()
+	if !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("Output() error = %v, want it to wrap exec.ErrNotFound", err)
+	}
+}
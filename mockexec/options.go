@@ -0,0 +1,44 @@
+package mockexec
+
+import "time"
+
+// MockCommandOption configures a MockShellCommand built by NewMockCommand.
+type MockCommandOption func(*MockShellCommand)
+
+// WithStdout sets the command's stdout.
+func WithStdout(output string) MockCommandOption {
+	return func(sc *MockShellCommand) { sc.Stdout = []byte(output) }
+}
+
+// WithStderr sets the command's stderr.
+func WithStderr(output string) MockCommandOption {
+	return func(sc *MockShellCommand) { sc.Stderr = []byte(output) }
+}
+
+// WithErr sets the error Output/CombinedOutput/Run return. Wait does not
+// return it.
+func WithErr(err error) MockCommandOption {
+	return func(sc *MockShellCommand) { sc.Err = err }
+}
+
+// WithExitCode sets the value ExitCode() reports.
+func WithExitCode(code int) MockCommandOption {
+	return func(sc *MockShellCommand) { sc.ExitCodeValue = code }
+}
+
+// WithDelay sets how long Output/CombinedOutput/Run sleeps before producing
+// its response.
+func WithDelay(d time.Duration) MockCommandOption {
+	return func(sc *MockShellCommand) { sc.Delay = d }
+}
+
+// NewMockCommand builds a MockShellCommand from functional options, so the
+// common case (a fixed stdout/stderr/exit code/delay) is a one-liner while
+// uncommon cases remain composable with a literal MockShellCommand.
+func NewMockCommand(opts ...MockCommandOption) *MockShellCommand {
+	sc := &MockShellCommand{}
+	for _, opt := range opts {
+		opt(sc)
+	}
+	return sc
+}
@@ -0,0 +1,46 @@
+package mockexec
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_NewMockCommand(t *testing.T) {
+	newCmd := func() *MockShellCommand {
+		return NewMockCommand(
+			WithStdout("hello\n"),
+			WithStderr("warn\n"),
+			WithExitCode(2),
+			WithDelay(5*time.Millisecond),
+		)
+	}
+
+	start := time.Now()
+	out, err := newCmd().Output()
+	elapsed := time.Since(start)
+
+	if err != nil || string(out) != "hello\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "hello\n")
+	}
+	if elapsed < 5*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least the configured 5ms delay", elapsed)
+	}
+	if cmd := newCmd(); cmd.ExitCode() != 2 {
+		t.Errorf("ExitCode() = %d, want 2", cmd.ExitCode())
+	}
+
+	combined, err := newCmd().CombinedOutput()
+	if err != nil || string(combined) != "hello\nwarn\n" {
+		t.Errorf("CombinedOutput() = (%q, %v), want (%q, nil)", combined, err, "hello\nwarn\n")
+	}
+}
+
+func Test_NewMockCommand_WithErr(t *testing.T) {
+	wantErr := errors.New("boom")
+	cmd := NewMockCommand(WithErr(wantErr))
+
+	if _, err := cmd.Output(); err != wantErr {
+		t.Errorf("Output() error = %v, want %v", err, wantErr)
+	}
+}
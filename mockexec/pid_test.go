@@ -0,0 +1,26 @@
+package mockexec
+
+import "testing"
+
+func Test_MockShellCommand_Pid_DeterministicWhenUnset(t *testing.T) {
+	a := &MockShellCommand{}
+	b := &MockShellCommand{}
+
+	if a.Pid() == 0 || b.Pid() == 0 {
+		t.Fatalf("Pid() = (%d, %d), want both nonzero", a.Pid(), b.Pid())
+	}
+	if a.Pid() == b.Pid() {
+		t.Errorf("Pid() returned the same value for two distinct mocks: %d", a.Pid())
+	}
+	if a.Pid() != a.Pid() {
+		t.Error("Pid() should return the same value on repeated calls")
+	}
+}
+
+func Test_MockShellCommand_Pid_HonorsPidValue(t *testing.T) {
+	sc := &MockShellCommand{PidValue: 4242}
+
+	if got := sc.Pid(); got != 4242 {
+		t.Errorf("Pid() = %d, want 4242", got)
+	}
+}
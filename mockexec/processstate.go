@@ -0,0 +1,33 @@
+package mockexec
+
+import "time"
+
+// ProcessState is the subset of *os.ProcessState application code typically
+// inspects after Wait: exit status and basic CPU accounting. *os.ProcessState
+// satisfies it directly, so ExecShellCommand.ProcessState can hand one back
+// without conversion; MockShellCommand hands back its ProcessStateValue
+// instead (nil unless set, e.g. to a FakeProcessState).
+type ProcessState interface {
+	ExitCode() int
+	Success() bool
+	UserTime() time.Duration
+	SystemTime() time.Duration
+}
+
+// FakeProcessState is a ProcessState a MockShellCommand can be configured to
+// return, for tests that assert on resource usage or Success() rather than
+// just the output/error MockShellCommand's simpler fields cover.
+type FakeProcessState struct {
+	ExitCodeValue   int
+	SuccessValue    bool
+	UserTimeValue   time.Duration
+	SystemTimeValue time.Duration
+}
+
+func (p FakeProcessState) ExitCode() int { return p.ExitCodeValue }
+
+func (p FakeProcessState) Success() bool { return p.SuccessValue }
+
+func (p FakeProcessState) UserTime() time.Duration { return p.UserTimeValue }
+
+func (p FakeProcessState) SystemTime() time.Duration { return p.SystemTimeValue }
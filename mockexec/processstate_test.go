@@ -0,0 +1,32 @@
+package mockexec
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_MockShellCommand_ProcessState_NilByDefault(t *testing.T) {
+	sc := &MockShellCommand{}
+	if sc.ProcessState() != nil {
+		t.Errorf("ProcessState() = %v, want nil", sc.ProcessState())
+	}
+}
+
+func Test_MockShellCommand_ProcessState_ReturnsConfiguredValue(t *testing.T) {
+	sc := &MockShellCommand{
+		ProcessStateValue: FakeProcessState{
+			ExitCodeValue:   1,
+			SuccessValue:    false,
+			UserTimeValue:   50 * time.Millisecond,
+			SystemTimeValue: 10 * time.Millisecond,
+		},
+	}
+
+	ps := sc.ProcessState()
+	if ps == nil {
+		t.Fatal("ProcessState() = nil, want a FakeProcessState")
+	}
+	if ps.ExitCode() != 1 || ps.Success() || ps.UserTime() != 50*time.Millisecond || ps.SystemTime() != 10*time.Millisecond {
+		t.Errorf("ProcessState() = %+v, want ExitCode=1 Success=false UserTime=50ms SystemTime=10ms", ps)
+	}
+}
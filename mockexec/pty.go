@@ -0,0 +1,110 @@
+package mockexec
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/creack/pty"
+)
+
+// TTYAware is implemented by an IShellCommand that can report whether it's
+// attached to a terminal, so application code that branches on TTY-ness
+// (many CLIs emit color, progress bars, or different line-wrapping only
+// when stdout is a terminal) can probe it uniformly instead of type-
+// switching on concrete command types:
+//
+//	if tty, ok := cmd.(TTYAware); ok && tty.IsTTY() { ... }
+//
+// PTYShellCommand always reports true. MockShellCommand reports its TTY
+// field, which defaults to false like a redirected pipe.
+type TTYAware interface {
+	IsTTY() bool
+}
+
+// PTYShellCommand is an IShellCommand that runs the real command attached
+// to a pseudo-terminal instead of plain exec.Cmd pipes, for exercising
+// code paths that change behavior based on isatty. StdoutPipe and
+// StdinPipe both return the pty master, since a real terminal has no
+// separate stdout/stdin pipes the way redirected pipes do.
+type PTYShellCommand struct {
+	ExecShellCommand
+	ptmx *os.File
+}
+
+// NewPTYCommander returns a ShellCommanderFunc backed by a pseudo-terminal
+// instead of plain exec.Cmd pipes, so a downstream tool sees os.Stdout as
+// a terminal the same way it would run interactively. Opt into this only
+// where that distinction matters for the test; NewExecShellCommander
+// remains the default.
+func NewPTYCommander() ShellCommanderFunc {
+	return func(name string, arg ...string) IShellCommand {
+		name, arg = wrapForShellScripts(name, arg)
+		return &PTYShellCommand{ExecShellCommand: ExecShellCommand{Cmd: exec.Command(name, arg...)}}
+	}
+}
+
+// IsTTY always returns true: a PTYShellCommand's child is always attached
+// to a pseudo-terminal.
+func (p *PTYShellCommand) IsTTY() bool {
+	return true
+}
+
+func (p *PTYShellCommand) Start() error {
+	f, err := pty.Start(p.Cmd)
+	if err != nil {
+		return err
+	}
+	p.ptmx = f
+	return nil
+}
+
+func (p *PTYShellCommand) Wait() error {
+	err := p.Cmd.Wait()
+	if p.ptmx != nil {
+		p.ptmx.Close()
+	}
+	return err
+}
+
+// Output starts the command, reads everything written to the pty until
+// the child exits and the kernel closes its end, then waits for exit -
+// exec.Cmd.Output's contract, adapted since a pty has no separate stdout
+// pipe to read in isolation from stdin.
+func (p *PTYShellCommand) Output() ([]byte, error) {
+	if err := p.Start(); err != nil {
+		return nil, err
+	}
+	out, _ := io.ReadAll(p.ptmx)
+	return out, p.Wait()
+}
+
+// CombinedOutput is Output: a pty has no separate stderr stream to merge in.
+func (p *PTYShellCommand) CombinedOutput() ([]byte, error) {
+	return p.Output()
+}
+
+func (p *PTYShellCommand) Run() error {
+	_, err := p.Output()
+	return err
+}
+
+// StdoutPipe returns the pty master for reading, matching how a real
+// terminal multiplexes the child's output over one fd. Must be called
+// after Start.
+func (p *PTYShellCommand) StdoutPipe() (io.ReadCloser, error) {
+	if p.ptmx == nil {
+		return nil, errors.New("mockexec: StdoutPipe called before Start on a PTY-backed command")
+	}
+	return p.ptmx, nil
+}
+
+// StdinPipe returns the pty master for writing, wrapped so closing it
+// doesn't close the fd StdoutPipe is also using. Must be called after Start.
+func (p *PTYShellCommand) StdinPipe() (io.WriteCloser, error) {
+	if p.ptmx == nil {
+		return nil, errors.New("mockexec: StdinPipe called before Start on a PTY-backed command")
+	}
+	return nopWriteCloser{p.ptmx}, nil
+}
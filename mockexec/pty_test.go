@@ -0,0 +1,59 @@
+package mockexec
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+func Test_PTYShellCommand_IsTTY(t *testing.T) {
+	cmd := NewPTYCommander()("echo", "hi")
+	tty, ok := cmd.(TTYAware)
+	if !ok || !tty.IsTTY() {
+		t.Fatal("PTYShellCommand should satisfy TTYAware and report IsTTY() == true")
+	}
+}
+
+func Test_PTYShellCommand_Output_RunsRealCommandOverPTY(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping real pty exec in -short mode")
+	}
+
+	cmd := NewPTYCommander()("echo", "hello")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	if !scanner.Scan() || scanner.Text() != "hello" {
+		t.Errorf("Output() = %q, want first line %q", out, "hello")
+	}
+}
+
+func Test_PTYShellCommand_Output_ClosesPTYMaster(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping real pty exec in -short mode")
+	}
+
+	cmd := NewPTYCommander()("echo", "hello").(*PTYShellCommand)
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	if _, err := cmd.ptmx.Write([]byte("x")); err == nil {
+		t.Error("Output() should close the pty master, but it still accepted a write")
+	}
+}
+
+func Test_MockShellCommand_TTYField_SatisfiesTTYAware(t *testing.T) {
+	notTTY := &MockShellCommand{}
+	if tty, ok := (IShellCommand)(notTTY).(TTYAware); !ok || tty.IsTTY() {
+		t.Error("MockShellCommand{} should report IsTTY() == false by default")
+	}
+
+	isTTY := &MockShellCommand{TTY: true}
+	if tty, ok := (IShellCommand)(isTTY).(TTYAware); !ok || !tty.IsTTY() {
+		t.Error("MockShellCommand{TTY: true} should report IsTTY() == true")
+	}
+}
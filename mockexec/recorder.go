@@ -0,0 +1,208 @@
+package mockexec
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// CallRecord captures one invocation observed by a Recorder.
+type CallRecord struct {
+	Name      string
+	Args      []string
+	Dir       string
+	Env       []string
+	Stdin     string
+	Timestamp time.Time
+
+	// Output and ExitCode are filled in once the command has produced its
+	// result (Output/CombinedOutput/Run returned), so a spy on a real
+	// commander can be inspected after the fact without an AfterCall hook.
+	// Run records an empty Output: its stdout goes to the writer set via
+	// SetStdout and is not captured here.
+	Output   string
+	ExitCode int
+}
+
+// CallLog is a snapshot of the invocations a Recorder has observed, in call
+// order. It marshals to JSON like a plain slice, but adds WriteTo so the
+// log can be dumped straight to a file or CI artifact without an
+// intermediate json.Marshal call.
+type CallLog []CallRecord
+
+// MarshalJSON renders the log as a JSON array of CallRecord objects.
+func (l CallLog) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]CallRecord(l))
+}
+
+// WriteTo writes the log to w as indented JSON, for dumping a failed test's
+// invocation history into a CI log or artifact.
+func (l CallLog) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent([]CallRecord(l), "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// Recorder wraps a ShellCommanderFunc and records every invocation as a
+// CallRecord, so a test can assert on what was executed after the fact
+// instead of scripting printouts inside the mock itself.
+type Recorder struct {
+	next ShellCommanderFunc
+
+	// OnCall, if set, is called synchronously with each CallRecord as soon
+	// as the invocation is made (before the underlying command has
+	// produced anything), e.g. to emit a tracing span or wake a goroutine
+	// waiting for a command to have been issued.
+	OnCall func(CallRecord)
+	// AfterCall, if set, is called once the underlying command has
+	// produced its output, with the (by-then-complete) CallRecord and the
+	// response it returned.
+	AfterCall func(CallRecord, Response)
+
+	mu    sync.Mutex
+	calls []CallRecord
+}
+
+// NewRecorder returns a Recorder that delegates to next for the actual
+// IShellCommand behavior (e.g. a MockCommander.Commander, or any other
+// ShellCommanderFunc) while recording each call.
+func NewRecorder(next ShellCommanderFunc) *Recorder {
+	return &Recorder{next: next}
+}
+
+// NewSpyCommander returns a Recorder wrapping the real, exec-backed
+// commander, so application code runs for real while every invocation -
+// including its exit code and, for Output/CombinedOutput, its output - is
+// captured for later inspection.
+// Useful for characterizing legacy code before writing mocks, or for
+// auditing exactly what an integration test ran.
+func NewSpyCommander() *Recorder {
+	return NewRecorder(NewExecShellCommander)
+}
+
+// Calls returns every invocation recorded so far, in call order.
+func (r *Recorder) Calls() CallLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := make(CallLog, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (r *Recorder) Commander(name string, args ...string) IShellCommand {
+	record := &CallRecord{
+		Name:      name,
+		Args:      args,
+		Timestamp: time.Now(),
+	}
+	r.mu.Lock()
+	r.calls = append(r.calls, *record)
+	idx := len(r.calls) - 1
+	r.mu.Unlock()
+
+	if r.OnCall != nil {
+		r.OnCall(*record)
+	}
+
+	underlying := r.next(name, args...)
+	return &recordingShellCommand{IShellCommand: underlying, recorder: r, idx: idx}
+}
+
+// fireAfterCall fills in the matching CallRecord's Output/ExitCode now that
+// the command has produced its result, then invokes AfterCall (if set)
+// with the completed record and the response the command just produced.
+func (rc *recordingShellCommand) fireAfterCall(out []byte, err error) {
+	rc.recorder.mu.Lock()
+	rc.recorder.calls[rc.idx].Output = string(out)
+	rc.recorder.calls[rc.idx].ExitCode = rc.IShellCommand.ExitCode()
+	record := rc.recorder.calls[rc.idx]
+	rc.recorder.mu.Unlock()
+
+	if rc.recorder.AfterCall != nil {
+		rc.recorder.AfterCall(record, Response{Output: string(out), Err: err})
+	}
+}
+
+func (rc *recordingShellCommand) Output() ([]byte, error) {
+	out, err := rc.IShellCommand.Output()
+	rc.fireAfterCall(out, err)
+	return out, err
+}
+
+func (rc *recordingShellCommand) CombinedOutput() ([]byte, error) {
+	out, err := rc.IShellCommand.CombinedOutput()
+	rc.fireAfterCall(out, err)
+	return out, err
+}
+
+func (rc *recordingShellCommand) Run() error {
+	err := rc.IShellCommand.Run()
+	rc.fireAfterCall(nil, err)
+	return err
+}
+
+// recordingShellCommand wraps an IShellCommand so that calls which aren't
+// visible from the commander signature alone (SetDir, SetEnv, the bytes
+// written to stdin) still end up on the matching CallRecord.
+type recordingShellCommand struct {
+	IShellCommand
+	recorder *Recorder
+	idx      int
+}
+
+func (rc *recordingShellCommand) SetDir(dir string) {
+	rc.recorder.mu.Lock()
+	rc.recorder.calls[rc.idx].Dir = dir
+	rc.recorder.mu.Unlock()
+	rc.IShellCommand.SetDir(dir)
+}
+
+func (rc *recordingShellCommand) SetEnv(env []string) {
+	rc.recorder.mu.Lock()
+	rc.recorder.calls[rc.idx].Env = env
+	rc.recorder.mu.Unlock()
+	rc.IShellCommand.SetEnv(env)
+}
+
+// SetStdin captures everything readable from r onto the CallRecord (in
+// addition to forwarding it on to the wrapped command), so stdin set this
+// way shows up in CallRecord.Stdin the same as stdin written through
+// StdinPipe.
+func (rc *recordingShellCommand) SetStdin(r io.Reader) {
+	if r == nil {
+		rc.IShellCommand.SetStdin(r)
+		return
+	}
+	data, _ := io.ReadAll(r)
+	rc.recorder.mu.Lock()
+	rc.recorder.calls[rc.idx].Stdin += string(data)
+	rc.recorder.mu.Unlock()
+	rc.IShellCommand.SetStdin(bytes.NewReader(data))
+}
+
+func (rc *recordingShellCommand) StdinPipe() (io.WriteCloser, error) {
+	w, err := rc.IShellCommand.StdinPipe()
+	if err != nil {
+		return w, err
+	}
+	return &recordingWriteCloser{WriteCloser: w, rc: rc}, nil
+}
+
+type recordingWriteCloser struct {
+	io.WriteCloser
+	rc *recordingShellCommand
+}
+
+func (w *recordingWriteCloser) Write(p []byte) (int, error) {
+	n, err := w.WriteCloser.Write(p)
+	w.rc.recorder.mu.Lock()
+	w.rc.recorder.calls[w.rc.idx].Stdin += string(p[:n])
+	w.rc.recorder.mu.Unlock()
+	return n, err
+}
@@ -0,0 +1,135 @@
+package mockexec
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_Recorder_CapturesInvocations(t *testing.T) {
+	underlying := func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{OutputterFunc: func() ([]byte, error) { return []byte("ok"), nil }}
+	}
+	r := NewRecorder(underlying)
+
+	cmd := r.Commander("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.SetDir("/repo")
+	cmd.SetEnv([]string{"GIT_DIR=/repo/.git"})
+
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	calls := r.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("Calls() = %v, want 1 record", calls)
+	}
+	got := calls[0]
+	if got.Name != "git" || got.Dir != "/repo" || len(got.Env) != 1 {
+		t.Errorf("CallRecord = %+v, want Name=git Dir=/repo Env=[GIT_DIR=...]", got)
+	}
+}
+
+func Test_Recorder_SetStdin_CapturesContent(t *testing.T) {
+	underlying := func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{OutputterFunc: func() ([]byte, error) { return []byte("ok"), nil }}
+	}
+	r := NewRecorder(underlying)
+
+	cmd := r.Commander("kubectl", "apply", "-f", "-")
+	cmd.SetStdin(strings.NewReader(`{"kind": "Pod"}`))
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	calls := r.Calls()
+	if len(calls) != 1 || calls[0].Stdin != `{"kind": "Pod"}` {
+		t.Errorf("Calls() = %+v, want Stdin=%q", calls, `{"kind": "Pod"}`)
+	}
+}
+
+func Test_Recorder_OnCallAndAfterCall(t *testing.T) {
+	underlying := func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("ok\n")}
+	}
+	r := NewRecorder(underlying)
+
+	var onCallRecord CallRecord
+	onCallFired := 0
+	r.OnCall = func(rec CallRecord) {
+		onCallFired++
+		onCallRecord = rec
+	}
+
+	var afterCallRecord CallRecord
+	var afterCallResp Response
+	afterCallFired := 0
+	r.AfterCall = func(rec CallRecord, resp Response) {
+		afterCallFired++
+		afterCallRecord = rec
+		afterCallResp = resp
+	}
+
+	cmd := r.Commander("git", "status")
+	if onCallFired != 1 {
+		t.Fatalf("OnCall fired %d times before Output(), want 1", onCallFired)
+	}
+	if onCallRecord.Name != "git" {
+		t.Errorf("OnCall CallRecord.Name = %q, want %q", onCallRecord.Name, "git")
+	}
+	if afterCallFired != 0 {
+		t.Fatalf("AfterCall fired %d times before Output(), want 0", afterCallFired)
+	}
+
+	out, err := cmd.Output()
+	if err != nil || string(out) != "ok\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "ok\n")
+	}
+
+	if afterCallFired != 1 {
+		t.Fatalf("AfterCall fired %d times, want 1", afterCallFired)
+	}
+	if afterCallRecord.Name != "git" {
+		t.Errorf("AfterCall CallRecord.Name = %q, want %q", afterCallRecord.Name, "git")
+	}
+	if afterCallResp.Output != "ok\n" || afterCallResp.Err != nil {
+		t.Errorf("AfterCall Response = %+v, want {Output: %q, Err: nil}", afterCallResp, "ok\n")
+	}
+}
+
+func Test_CallLog_MarshalJSON(t *testing.T) {
+	underlying := func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("ok\n")}
+	}
+	r := NewRecorder(underlying)
+	r.Commander("git", "status")
+
+	data, err := json.Marshal(r.Calls())
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	var decoded []CallRecord
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0].Name != "git" {
+		t.Errorf("decoded = %+v, want one record for git", decoded)
+	}
+}
+
+func Test_CallLog_WriteTo(t *testing.T) {
+	underlying := func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("ok\n")}
+	}
+	r := NewRecorder(underlying)
+	r.Commander("git", "push", "origin", "main")
+
+	var buf bytes.Buffer
+	if _, err := r.Calls().WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"Name": "git"`) {
+		t.Errorf("WriteTo output missing expected field, got %s", buf.String())
+	}
+}
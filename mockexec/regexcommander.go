@@ -0,0 +1,62 @@
+package mockexec
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RegexRule pairs a pattern matched against the full, shell-quoted command
+// line with a Responder computed from its capture groups.
+type RegexRule struct {
+	Pattern   *regexp.Regexp
+	Responder func(matches []string) (stdout string, err error)
+}
+
+// RegexCommander dispatches by matching the full, shell-quoted command line
+// ("kubectl get pods -n kube-system -o json") against a list of regex
+// rules, so one rule can cover many invocations that per-arg matchers would
+// need many entries for - e.g. `^kubectl get pods -n (\w+) -o json$`
+// capturing the namespace once instead of one entry per namespace.
+type RegexCommander struct {
+	rules    []RegexRule
+	fallback ShellCommanderFunc
+}
+
+// NewRegexCommander returns an empty RegexCommander. Command lines matching
+// no rule fall through to SetDefault's handler, or panic if none was set.
+func NewRegexCommander() *RegexCommander {
+	return &RegexCommander{}
+}
+
+// AddRule appends a rule tried in the order added; the first rule whose
+// Pattern matches the command line wins.
+func (rc *RegexCommander) AddRule(pattern *regexp.Regexp, responder func(matches []string) (stdout string, err error)) {
+	rc.rules = append(rc.rules, RegexRule{Pattern: pattern, Responder: responder})
+}
+
+// SetDefault installs the handler used for command lines matching no rule.
+func (rc *RegexCommander) SetDefault(handler ShellCommanderFunc) {
+	rc.fallback = handler
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (rc *RegexCommander) Commander(name string, arg ...string) IShellCommand {
+	line := QuoteCommandLine(name, arg)
+	for _, rule := range rc.rules {
+		matches := rule.Pattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		responder := rule.Responder
+		return &MockShellCommand{
+			OutputterFunc: func() ([]byte, error) {
+				out, err := responder(matches)
+				return []byte(out), err
+			},
+		}
+	}
+	if rc.fallback != nil {
+		return rc.fallback(name, arg...)
+	}
+	panic(fmt.Sprintf("mockexec: no regex rule matches command line %q", line))
+}
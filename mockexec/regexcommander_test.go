@@ -0,0 +1,50 @@
+package mockexec
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func Test_RegexCommander_MatchesCaptureGroups(t *testing.T) {
+	rc := NewRegexCommander()
+	rc.AddRule(regexp.MustCompile(`^kubectl get pods -n ([\w-]+) -o json$`), func(matches []string) (string, error) {
+		return fmt.Sprintf(`{"namespace":%q}`+"\n", matches[1]), nil
+	})
+
+	out, err := rc.Commander("kubectl", "get", "pods", "-n", "kube-system", "-o", "json").Output()
+	if err != nil || string(out) != `{"namespace":"kube-system"}`+"\n" {
+		t.Errorf("Output() = (%q, %v), want namespace captured from command line", out, err)
+	}
+
+	out, err = rc.Commander("kubectl", "get", "pods", "-n", "default", "-o", "json").Output()
+	if err != nil || string(out) != `{"namespace":"default"}`+"\n" {
+		t.Errorf("Output() = (%q, %v), want namespace captured from command line", out, err)
+	}
+}
+
+func Test_RegexCommander_FallsThroughToDefault(t *testing.T) {
+	rc := NewRegexCommander()
+	rc.AddRule(regexp.MustCompile(`^kubectl get pods`), func(matches []string) (string, error) {
+		return "pods\n", nil
+	})
+	rc.SetDefault(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("fallback\n")}
+	})
+
+	out, err := rc.Commander("kubectl", "get", "nodes").Output()
+	if err != nil || string(out) != "fallback\n" {
+		t.Errorf("Output() = (%q, %v), want fallback handler's output", out, err)
+	}
+}
+
+func Test_RegexCommander_NoRuleNoDefault_Panics(t *testing.T) {
+	rc := NewRegexCommander()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Commander() did not panic for an unmatched command line with no default")
+		}
+	}()
+	rc.Commander("kubectl", "get", "nodes")
+}
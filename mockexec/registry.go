@@ -0,0 +1,52 @@
+package mockexec
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Registry dispatches to a per-binary-name ShellCommanderFunc, so a test
+// that mocks several binaries (git, docker, kubectl, ...) can register one
+// handler per name instead of writing a single function with a big switch.
+type Registry struct {
+	mu       sync.Mutex
+	handlers map[string]ShellCommanderFunc
+	fallback ShellCommanderFunc
+}
+
+// NewRegistry returns an empty Registry. Names with no registered handler
+// fall through to SetDefault's handler, or panic if none was set.
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]ShellCommanderFunc)}
+}
+
+// Register installs handler for the given binary name, replacing any
+// previously registered handler for that name.
+func (r *Registry) Register(name string, handler ShellCommanderFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[name] = handler
+}
+
+// SetDefault installs the handler used for names with no registered handler.
+func (r *Registry) SetDefault(handler ShellCommanderFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = handler
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (r *Registry) Commander(name string, args ...string) IShellCommand {
+	r.mu.Lock()
+	handler, ok := r.handlers[name]
+	fallback := r.fallback
+	r.mu.Unlock()
+
+	if ok {
+		return handler(name, args...)
+	}
+	if fallback != nil {
+		return fallback(name, args...)
+	}
+	panic(fmt.Sprintf("mockexec: no handler registered for command %q", name))
+}
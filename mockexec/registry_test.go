@@ -0,0 +1,35 @@
+package mockexec
+
+import "testing"
+
+func Test_Registry_DispatchesByName(t *testing.T) {
+	r := NewRegistry()
+	r.Register("git", func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{OutputterFunc: func() ([]byte, error) { return []byte("main"), nil }}
+	})
+	r.Register("docker", func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{OutputterFunc: func() ([]byte, error) { return []byte("1.2.3"), nil }}
+	})
+
+	gitOut, _ := r.Commander("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if string(gitOut) != "main" {
+		t.Errorf("git output = %q, want %q", gitOut, "main")
+	}
+
+	dockerOut, _ := r.Commander("docker", "version", "--format", "{{.Client.Version}}").Output()
+	if string(dockerOut) != "1.2.3" {
+		t.Errorf("docker output = %q, want %q", dockerOut, "1.2.3")
+	}
+}
+
+func Test_Registry_FallsThroughToDefault(t *testing.T) {
+	r := NewRegistry()
+	r.SetDefault(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{OutputterFunc: func() ([]byte, error) { return []byte("default"), nil }}
+	})
+
+	out, _ := r.Commander("kubectl", "get", "pods").Output()
+	if string(out) != "default" {
+		t.Errorf("output = %q, want %q", out, "default")
+	}
+}
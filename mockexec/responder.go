@@ -0,0 +1,106 @@
+package mockexec
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Responder produces the stdout/error for one matched invocation,
+// mirroring jarcoal/httpmock's Responder for command invocations instead
+// of HTTP requests.
+type Responder func() (string, error)
+
+// NewStringResponder returns a Responder that always returns body as
+// stdout, modeling httpmock.NewStringResponder. A non-zero exitCode
+// returns body alongside ExitError(exitCode, nil) instead of a nil error.
+func NewStringResponder(body string, exitCode int) Responder {
+	return func() (string, error) {
+		if exitCode != 0 {
+			return body, ExitError(exitCode, nil)
+		}
+		return body, nil
+	}
+}
+
+// ResponderCommander is an httpmock-style commander: register a Responder
+// for "name args..." with RegisterResponder, use Commander as the
+// ShellCommanderFunc, then use GetCallCountInfo to assert on what ran,
+// matching httpmock.RegisterResponder/GetCallCountInfo's ergonomics for
+// teams already used to them.
+type ResponderCommander struct {
+	mu          sync.Mutex
+	responders  map[string]Responder
+	callCounts  map[string]int
+	noResponder ShellCommanderFunc
+}
+
+// NewResponderCommander returns an empty ResponderCommander.
+func NewResponderCommander() *ResponderCommander {
+	return &ResponderCommander{
+		responders: make(map[string]Responder),
+		callCounts: make(map[string]int),
+	}
+}
+
+// RegisterResponder installs responder for invocations of name with args -
+// the space-joined argument string, e.g. "rev-parse --abbrev-ref HEAD" -
+// replacing any previously registered responder for that key.
+func (rc *ResponderCommander) RegisterResponder(name, args string, responder Responder) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.responders[responderKey(name, args)] = responder
+}
+
+// RegisterNoResponder installs handler for invocations matching no
+// registered responder, replacing the default behavior of panicking,
+// mirroring httpmock.RegisterNoResponder.
+func (rc *ResponderCommander) RegisterNoResponder(handler ShellCommanderFunc) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.noResponder = handler
+}
+
+// GetCallCountInfo returns, for every "name args" key invoked so far
+// (whether or not a responder was registered for it), how many times it
+// was invoked, mirroring httpmock.GetCallCountInfo's call-count map.
+func (rc *ResponderCommander) GetCallCountInfo() map[string]int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	counts := make(map[string]int, len(rc.callCounts))
+	for k, v := range rc.callCounts {
+		counts[k] = v
+	}
+	return counts
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (rc *ResponderCommander) Commander(name string, arg ...string) IShellCommand {
+	key := responderKey(name, strings.Join(arg, " "))
+
+	rc.mu.Lock()
+	rc.callCounts[key]++
+	responder, ok := rc.responders[key]
+	fallback := rc.noResponder
+	rc.mu.Unlock()
+
+	if ok {
+		return &MockShellCommand{
+			OutputterFunc: func() ([]byte, error) {
+				out, err := responder()
+				return []byte(out), err
+			},
+		}
+	}
+	if fallback != nil {
+		return fallback(name, arg...)
+	}
+	panic(fmt.Sprintf("mockexec: no responder registered for %q", key))
+}
+
+func responderKey(name, args string) string {
+	if args == "" {
+		return name
+	}
+	return name + " " + args
+}
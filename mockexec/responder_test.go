@@ -0,0 +1,63 @@
+package mockexec
+
+import "testing"
+
+func Test_ResponderCommander_RegisterResponder_MatchesNameAndArgs(t *testing.T) {
+	rc := NewResponderCommander()
+	rc.RegisterResponder("git", "rev-parse --abbrev-ref HEAD", NewStringResponder("main\n", 0))
+
+	out, err := rc.Commander("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil || string(out) != "main\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "main\n")
+	}
+}
+
+func Test_ResponderCommander_NonZeroExitCode_ReturnsExitError(t *testing.T) {
+	rc := NewResponderCommander()
+	rc.RegisterResponder("git", "push", NewStringResponder("", 1))
+
+	_, err := rc.Commander("git", "push").Output()
+	if err == nil {
+		t.Error("Output() should have returned an error for exit code 1")
+	}
+}
+
+func Test_ResponderCommander_GetCallCountInfo_TracksInvocations(t *testing.T) {
+	rc := NewResponderCommander()
+	rc.RegisterResponder("git", "status", NewStringResponder("clean\n", 0))
+
+	rc.Commander("git", "status").Output()
+	rc.Commander("git", "status").Output()
+	func() {
+		defer func() { recover() }()
+		rc.Commander("git", "log") // no responder registered, falls through to panic
+	}()
+
+	info := rc.GetCallCountInfo()
+	if info["git status"] != 2 {
+		t.Errorf("GetCallCountInfo()[%q] = %d, want 2", "git status", info["git status"])
+	}
+}
+
+func Test_ResponderCommander_RegisterNoResponder_HandlesUnregisteredCalls(t *testing.T) {
+	rc := NewResponderCommander()
+	rc.RegisterNoResponder(func(name string, arg ...string) IShellCommand {
+		return &MockShellCommand{Stdout: []byte("fallback\n")}
+	})
+
+	out, err := rc.Commander("git", "log").Output()
+	if err != nil || string(out) != "fallback\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "fallback\n")
+	}
+}
+
+func Test_ResponderCommander_NoResponderNoFallback_Panics(t *testing.T) {
+	rc := NewResponderCommander()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Commander() did not panic for an unregistered command with no fallback")
+		}
+	}()
+	rc.Commander("git", "log")
+}
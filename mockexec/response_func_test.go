@@ -0,0 +1,34 @@
+package mockexec
+
+import "testing"
+
+func Test_Expectation_ReturnFunc_EchoesArgsAndDir(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("echo-tool", Any()).ReturnFunc(
+		func(name string, args []string, dir string, env []string) ([]byte, []byte, error) {
+			return []byte(dir + ":" + args[0] + "\n"), nil, nil
+		},
+	)
+
+	cmd := mc.Commander("echo-tool", "hello")
+	cmd.SetDir("mydir")
+
+	out, err := cmd.Output()
+	if err != nil || string(out) != "mydir:hello\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "mydir:hello\n")
+	}
+}
+
+func Test_Expectation_ReturnFunc_CombinedOutput(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("tool").ReturnFunc(
+		func(name string, args []string, dir string, env []string) ([]byte, []byte, error) {
+			return []byte("out\n"), []byte("err\n"), nil
+		},
+	)
+
+	out, err := mc.Commander("tool").CombinedOutput()
+	if err != nil || string(out) != "out\nerr\n" {
+		t.Fatalf("CombinedOutput() = (%q, %v), want (%q, nil)", out, err, "out\nerr\n")
+	}
+}
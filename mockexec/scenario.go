@@ -0,0 +1,97 @@
+package mockexec
+
+import "sync"
+
+// ScenarioBranch is one edge out of a ScenarioStep: if the caller's next
+// command matches Cmd/Args, either it runs normally - producing
+// Stdout/Stderr/Err and moving to the step named Goto - or, if Fail is set,
+// the scenario fails immediately via TestingT.Fatalf with Fail as the
+// reason, instead of responding at all.
+type ScenarioBranch struct {
+	Cmd  string
+	Args []string
+
+	Stdout string
+	Stderr string
+	Err    error
+	Goto   string
+
+	// Fail, if non-empty, makes taking this branch a scenario failure
+	// reported via Fatalf, e.g. "the caller ran rollback, which this
+	// scenario treats as a deploy gone wrong".
+	Fail string
+}
+
+// ScenarioStep names one point in a Scenario and the branches reachable
+// from it. The first branch whose Cmd/Args matches the invocation wins; a
+// command matching none of them is itself a scenario failure.
+type ScenarioStep struct {
+	Name     string
+	Branches []ScenarioBranch
+}
+
+// Scenario is a named, branching sequence of expected commands: each step
+// says which command(s) are valid next and where each leads, so a
+// multi-command workflow (e.g. a deploy pipeline) can be described as a
+// small graph instead of a flat, linear expectation list - and "the caller
+// did the wrong thing next" is a distinct, named failure rather than just
+// an unexpected call.
+type Scenario struct {
+	Name  string
+	Start string
+	Steps []ScenarioStep
+}
+
+// ScenarioCommander runs one Scenario, reporting any failing branch, or any
+// command matching no branch of the current step, to t via Fatalf.
+type ScenarioCommander struct {
+	mu       sync.Mutex
+	t        TestingT
+	scenario Scenario
+	current  string
+	byName   map[string]*ScenarioStep
+}
+
+// NewScenarioCommander returns a commander that walks scenario starting at
+// scenario.Start, reporting any divergence to t.
+func NewScenarioCommander(t TestingT, scenario Scenario) *ScenarioCommander {
+	byName := make(map[string]*ScenarioStep, len(scenario.Steps))
+	for i := range scenario.Steps {
+		byName[scenario.Steps[i].Name] = &scenario.Steps[i]
+	}
+	return &ScenarioCommander{t: t, scenario: scenario, current: scenario.Start, byName: byName}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (sc *ScenarioCommander) Commander(name string, args ...string) IShellCommand {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	step, ok := sc.byName[sc.current]
+	if !ok {
+		sc.t.Fatalf("mockexec: scenario %q: step %q does not exist", sc.scenario.Name, sc.current)
+		return &MockShellCommand{}
+	}
+
+	for _, b := range step.Branches {
+		if b.Cmd != name || !equalStrings(b.Args, args) {
+			continue
+		}
+		if b.Fail != "" {
+			sc.t.Fatalf("mockexec: scenario %q: step %q: %s", sc.scenario.Name, step.Name, b.Fail)
+			return &MockShellCommand{}
+		}
+		sc.current = b.Goto
+		return &MockShellCommand{Stdout: []byte(b.Stdout), Stderr: []byte(b.Stderr), Err: b.Err}
+	}
+
+	sc.t.Fatalf("mockexec: scenario %q: step %q: command %v %v matches no branch", sc.scenario.Name, step.Name, name, args)
+	return &MockShellCommand{}
+}
+
+// State returns the name of the step the scenario is currently on.
+func (sc *ScenarioCommander) State() string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.current
+}
@@ -0,0 +1,79 @@
+package mockexec
+
+import "testing"
+
+func deployScenario() Scenario {
+	return Scenario{
+		Name:  "deploy",
+		Start: "build",
+		Steps: []ScenarioStep{
+			{
+				Name: "build",
+				Branches: []ScenarioBranch{
+					{Cmd: "make", Args: []string{"build"}, Stdout: "built\n", Goto: "release"},
+				},
+			},
+			{
+				Name: "release",
+				Branches: []ScenarioBranch{
+					{Cmd: "make", Args: []string{"release"}, Stdout: "released\n", Goto: "done"},
+					{Cmd: "make", Args: []string{"rollback"}, Fail: "rolled back instead of releasing"},
+				},
+			},
+			{
+				Name:     "done",
+				Branches: []ScenarioBranch{},
+			},
+		},
+	}
+}
+
+func Test_ScenarioCommander_FollowsHappyPathBranch(t *testing.T) {
+	sc := NewScenarioCommander(t, deployScenario())
+
+	out, err := sc.Commander("make", "build").Output()
+	if err != nil || string(out) != "built\n" {
+		t.Fatalf("build step Output() = (%q, %v), want (%q, nil)", out, err, "built\n")
+	}
+	if got := sc.State(); got != "release" {
+		t.Errorf("State() = %q, want %q", got, "release")
+	}
+
+	out, err = sc.Commander("make", "release").Output()
+	if err != nil || string(out) != "released\n" {
+		t.Fatalf("release step Output() = (%q, %v), want (%q, nil)", out, err, "released\n")
+	}
+	if got := sc.State(); got != "done" {
+		t.Errorf("State() = %q, want %q", got, "done")
+	}
+}
+
+func Test_ScenarioCommander_FailBranch_FailsViaFatalf(t *testing.T) {
+	fake := &fakeTestingT{}
+	sc := NewScenarioCommander(fake, deployScenario())
+
+	sc.Commander("make", "build").Output()
+
+	func() {
+		defer func() { recover() }()
+		sc.Commander("make", "rollback")
+	}()
+
+	if !fake.failed {
+		t.Error("Commander() for the rollback branch should have called Fatalf")
+	}
+}
+
+func Test_ScenarioCommander_UnmatchedCommand_FailsViaFatalf(t *testing.T) {
+	fake := &fakeTestingT{}
+	sc := NewScenarioCommander(fake, deployScenario())
+
+	func() {
+		defer func() { recover() }()
+		sc.Commander("make", "test")
+	}()
+
+	if !fake.failed {
+		t.Error("Commander() for an unmatched command should have called Fatalf")
+	}
+}
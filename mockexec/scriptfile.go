@@ -0,0 +1,179 @@
+package mockexec
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ScriptRule is one fake-command rule parsed from a script file: Match is
+// a shell glob (see lineGlobMatch) tested against the full, shell-quoted
+// command line.
+type ScriptRule struct {
+	Match    string
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// LoadScriptRules parses path as a bats-mock-style fake-command script: one
+// or more rules, each a block of "key: value" lines separated by a blank
+// line, so non-Go teammates can maintain CLI fixtures without touching Go
+// closures. Recognized keys are match, exit, stdout, and stderr; stdout and
+// stderr accept either a literal single-line value or a heredoc:
+//
+//	match: git rev-parse --abbrev-ref HEAD
+//	exit: 0
+//	stdout: <<END
+//	main
+//	END
+//
+//	match: git push *
+//	exit: 1
+//	stderr: <<END
+//	! [rejected]
+//	END
+func LoadScriptRules(path string) ([]ScriptRule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rules []ScriptRule
+	cur := ScriptRule{}
+	haveRule := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			if haveRule {
+				rules = append(rules, cur)
+				cur = ScriptRule{}
+				haveRule = false
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("mockexec: %s: malformed line %q, want key: value", path, line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if delim, isHeredoc := strings.CutPrefix(value, "<<"); isHeredoc {
+			body, err := readHeredoc(scanner, strings.TrimSpace(delim))
+			if err != nil {
+				return nil, fmt.Errorf("mockexec: %s: %w", path, err)
+			}
+			value = body
+		}
+
+		haveRule = true
+		switch key {
+		case "match":
+			cur.Match = value
+		case "exit":
+			code, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("mockexec: %s: invalid exit code %q: %w", path, value, err)
+			}
+			cur.ExitCode = code
+		case "stdout":
+			cur.Stdout = value
+		case "stderr":
+			cur.Stderr = value
+		default:
+			return nil, fmt.Errorf("mockexec: %s: unrecognized key %q", path, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if haveRule {
+		rules = append(rules, cur)
+	}
+	return rules, nil
+}
+
+// readHeredoc collects lines from scanner up to (not including) a line
+// exactly equal to delim, joined with "\n" and terminated by a trailing
+// "\n", matching how a shell heredoc's body is normally consumed by a
+// command.
+func readHeredoc(scanner *bufio.Scanner, delim string) (string, error) {
+	var lines []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == delim {
+			return strings.Join(lines, "\n") + "\n", nil
+		}
+		lines = append(lines, line)
+	}
+	return "", fmt.Errorf("unterminated heredoc, want closing %q", delim)
+}
+
+// ScriptCommander serves responses loaded from fake-command script files
+// via LoadScriptRules, matching ScriptRule.Match as a shell glob against
+// the full, shell-quoted command line.
+type ScriptCommander struct {
+	rules []ScriptRule
+}
+
+// NewScriptCommander returns a commander serving rules, tried in order; the
+// first whose Match globs the command line wins.
+func NewScriptCommander(rules []ScriptRule) *ScriptCommander {
+	return &ScriptCommander{rules: rules}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (sc *ScriptCommander) Commander(name string, arg ...string) IShellCommand {
+	line := QuoteCommandLine(name, arg)
+	for _, rule := range sc.rules {
+		if !lineGlobMatch(rule.Match, line) {
+			continue
+		}
+		if rule.ExitCode != 0 {
+			return &MockShellCommand{
+				Stdout:        []byte(rule.Stdout),
+				Err:           ExitError(rule.ExitCode, []byte(rule.Stderr)),
+				ExitCodeValue: rule.ExitCode,
+			}
+		}
+		return &MockShellCommand{Stdout: []byte(rule.Stdout), Stderr: []byte(rule.Stderr)}
+	}
+	panic(fmt.Sprintf("mockexec: no script rule matches command line %q", line))
+}
+
+// lineGlobMatch reports whether line matches pattern, where "*" matches any
+// sequence of characters (including "/") and "?" matches any single
+// character, all other characters matching literally. Unlike path.Match or
+// filepath.Match, "*" is not special-cased at "/" boundaries, since pattern
+// is matched against a whole shell command line - file paths, URLs,
+// branch names, and image refs all routinely contain slashes, and a rule
+// like "deploy *" is meant to match "deploy /opt/app" just as readily as
+// "deploy app".
+func lineGlobMatch(pattern, line string) bool {
+	var re strings.Builder
+	re.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			re.WriteString(".*")
+		case '?':
+			re.WriteString(".")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	re.WriteString("$")
+	return regexp.MustCompile(re.String()).MatchString(line)
+}
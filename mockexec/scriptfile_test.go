@@ -0,0 +1,86 @@
+package mockexec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_LoadScriptRules_ParsesMatchExitHeredoc(t *testing.T) {
+	scriptPath := filepath.Join(t.TempDir(), "fake-git")
+	const script = `match: git rev-parse --abbrev-ref HEAD
+exit: 0
+stdout: <<END
+main
+END
+
+match: git push *
+exit: 1
+stderr: <<END
+! [rejected]
+error: failed to push
+END
+`
+	if err := os.WriteFile(scriptPath, []byte(script), 0o644); err != nil {
+		t.Fatalf("failed to write script file: %v", err)
+	}
+
+	rules, err := LoadScriptRules(scriptPath)
+	if err != nil {
+		t.Fatalf("LoadScriptRules() failed: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("LoadScriptRules() returned %d rules, want 2", len(rules))
+	}
+
+	sc := NewScriptCommander(rules)
+
+	out, err := sc.Commander("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil || string(out) != "main\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "main\n")
+	}
+
+	_, err = sc.Commander("git", "push", "origin", "main").Output()
+	if err == nil {
+		t.Error("Output() for the rejected push should have returned an error")
+	}
+}
+
+func Test_LoadScriptRules_MalformedLine_ReturnsError(t *testing.T) {
+	scriptPath := filepath.Join(t.TempDir(), "fake-git")
+	if err := os.WriteFile(scriptPath, []byte("this is not a directive\n"), 0o644); err != nil {
+		t.Fatalf("failed to write script file: %v", err)
+	}
+
+	if _, err := LoadScriptRules(scriptPath); err == nil {
+		t.Error("LoadScriptRules() should have failed on a malformed line")
+	}
+}
+
+func Test_ScriptCommander_MatchGlob_CrossesSlashes(t *testing.T) {
+	sc := NewScriptCommander([]ScriptRule{
+		{Match: "deploy *", Stdout: "deployed\n"},
+		{Match: "docker run *", Stdout: "ran\n"},
+	})
+
+	out, err := sc.Commander("deploy", "/opt/app").Output()
+	if err != nil || string(out) != "deployed\n" {
+		t.Errorf(`Commander("deploy", "/opt/app").Output() = (%q, %v), want (%q, nil)`, out, err, "deployed\n")
+	}
+
+	out, err = sc.Commander("docker", "run", "registry/app:tag").Output()
+	if err != nil || string(out) != "ran\n" {
+		t.Errorf(`Commander("docker", "run", "registry/app:tag").Output() = (%q, %v), want (%q, nil)`, out, err, "ran\n")
+	}
+}
+
+func Test_ScriptCommander_NoRuleMatches_Panics(t *testing.T) {
+	sc := NewScriptCommander([]ScriptRule{{Match: "git status", Stdout: "clean\n"}})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Commander() did not panic for an unmatched command line")
+		}
+	}()
+	sc.Commander("git", "log")
+}
@@ -0,0 +1,73 @@
+package mockexec
+
+import "sync"
+
+// Step is one scripted invocation in a SequenceCommander's script: Cmd/Args
+// is what must be invoked next, and Stdout/Stderr/Err is what it produces.
+type Step struct {
+	Cmd    string
+	Args   []string
+	Stdout string
+	Stderr string
+	Err    error
+}
+
+// SequenceCommander is a commander that asserts invocations happen in
+// exactly the given order with exactly the given arguments, failing fast
+// (via TestingT.Fatalf) on any divergence. It's well suited to testing a
+// multi-command workflow where order matters as much as content.
+type SequenceCommander struct {
+	mu    sync.Mutex
+	t     TestingT
+	steps []Step
+	pos   int
+}
+
+// NewSequenceCommander returns a commander that serves steps in order,
+// reporting any unexpected command or ordering violation to t.
+func NewSequenceCommander(t TestingT, steps []Step) *SequenceCommander {
+	return &SequenceCommander{t: t, steps: steps}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (sc *SequenceCommander) Commander(name string, args ...string) IShellCommand {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.pos >= len(sc.steps) {
+		sc.t.Fatalf("mockexec: unexpected command %v %v: all %d scripted steps already consumed", name, args, len(sc.steps))
+		return &MockShellCommand{}
+	}
+
+	step := sc.steps[sc.pos]
+	if step.Cmd != name || !equalStrings(step.Args, args) {
+		sc.t.Fatalf("mockexec: step %d: got command %v %v, want %v %v", sc.pos, name, args, step.Cmd, step.Args)
+		return &MockShellCommand{}
+	}
+	sc.pos++
+	return &MockShellCommand{Stdout: []byte(step.Stdout), Stderr: []byte(step.Stderr), Err: step.Err}
+}
+
+// Finish fails t if any scripted step was never invoked, catching a
+// workflow that stopped early.
+func (sc *SequenceCommander) Finish() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.pos < len(sc.steps) {
+		sc.t.Fatalf("mockexec: only %d of %d scripted steps were invoked; next expected %v %v",
+			sc.pos, len(sc.steps), sc.steps[sc.pos].Cmd, sc.steps[sc.pos].Args)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package mockexec
+
+import "testing"
+
+func Test_SequenceCommander_ServesStepsInOrder(t *testing.T) {
+	sc := NewSequenceCommander(t, []Step{
+		{Cmd: "git", Args: []string{"pull"}, Stdout: "pulled\n"},
+		{Cmd: "make", Args: []string{"build"}, Stdout: "built\n"},
+		{Cmd: "make", Args: []string{"test"}, Stdout: "passed\n"},
+	})
+
+	out, err := sc.Commander("git", "pull").Output()
+	if err != nil || string(out) != "pulled\n" {
+		t.Fatalf("step 0 Output() = (%q, %v), want (%q, nil)", out, err, "pulled\n")
+	}
+	out, err = sc.Commander("make", "build").Output()
+	if err != nil || string(out) != "built\n" {
+		t.Fatalf("step 1 Output() = (%q, %v), want (%q, nil)", out, err, "built\n")
+	}
+	out, err = sc.Commander("make", "test").Output()
+	if err != nil || string(out) != "passed\n" {
+		t.Fatalf("step 2 Output() = (%q, %v), want (%q, nil)", out, err, "passed\n")
+	}
+
+	sc.Finish()
+}
+
+func Test_SequenceCommander_FailsOnWrongOrder(t *testing.T) {
+	fake := &fakeTestingT{}
+	sc := NewSequenceCommander(fake, []Step{
+		{Cmd: "git", Args: []string{"pull"}},
+		{Cmd: "make", Args: []string{"build"}},
+	})
+
+	sc.Commander("make", "build") // out of order: git pull was expected first
+
+	if !fake.failed {
+		t.Error("expected Fatalf to be called for an out-of-order command")
+	}
+}
+
+func Test_SequenceCommander_FinishFailsIfStepsUnconsumed(t *testing.T) {
+	fake := &fakeTestingT{}
+	sc := NewSequenceCommander(fake, []Step{
+		{Cmd: "git", Args: []string{"pull"}},
+		{Cmd: "make", Args: []string{"build"}},
+	})
+
+	sc.Commander("git", "pull")
+	sc.Finish()
+
+	if !fake.failed {
+		t.Error("expected Fatalf to be called for unconsumed steps")
+	}
+}
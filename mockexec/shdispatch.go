@@ -0,0 +1,115 @@
+package mockexec
+
+import (
+	"fmt"
+)
+
+// TokenizeShellCommand splits a shell command-line string into words the
+// way a POSIX shell would: whitespace separates words, and single or double
+// quotes (and backslash escapes outside quotes) let a word contain
+// whitespace or the characters used to join commands ("&&", ";").
+func TokenizeShellCommand(s string) ([]string, error) {
+	var tokens []string
+	var cur []rune
+	inWord := false
+	var quote rune
+
+	flush := func() {
+		if inWord {
+			tokens = append(tokens, string(cur))
+			cur = nil
+			inWord = false
+		}
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur = append(cur, r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			cur = append(cur, runes[i])
+			inWord = true
+		case r == ' ' || r == '\t':
+			flush()
+		default:
+			cur = append(cur, r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("mockexec: unterminated %c quote in %q", quote, s)
+	}
+	flush()
+	return tokens, nil
+}
+
+// splitOnToken splits tokens into segments wherever sep occurs, dropping
+// the separator itself, mirroring how a shell splits a compound command on
+// "&&".
+func splitOnToken(tokens []string, sep string) [][]string {
+	var segments [][]string
+	cur := []string{}
+	for _, t := range tokens {
+		if t == sep {
+			segments = append(segments, cur)
+			cur = []string{}
+			continue
+		}
+		cur = append(cur, t)
+	}
+	return append(segments, cur)
+}
+
+// ShDispatcher wraps a ShellCommanderFunc so that invocations of
+// `sh -c "<script>"` are tokenized (respecting quoting) and each inner
+// command, joined by "&&", is dispatched to the wrapped commander, instead
+// of forcing expectations to match the opaque, unparsed script string.
+// Any invocation that isn't `sh -c ...` passes straight through.
+type ShDispatcher struct {
+	inner ShellCommanderFunc
+}
+
+// NewShDispatcher wraps inner so `sh -c` scripts are parsed before dispatch.
+func NewShDispatcher(inner ShellCommanderFunc) *ShDispatcher {
+	return &ShDispatcher{inner: inner}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (d *ShDispatcher) Commander(name string, args ...string) IShellCommand {
+	if name != "sh" || len(args) < 2 || args[0] != "-c" {
+		return d.inner(name, args...)
+	}
+
+	tokens, err := TokenizeShellCommand(args[1])
+	if err != nil {
+		return &MockShellCommand{Err: err}
+	}
+	segments := splitOnToken(tokens, "&&")
+
+	return &MockShellCommand{
+		OutputterFunc: func() ([]byte, error) {
+			var combined []byte
+			for _, seg := range segments {
+				if len(seg) == 0 {
+					continue
+				}
+				out, err := d.inner(seg[0], seg[1:]...).Output()
+				combined = append(combined, out...)
+				if err != nil {
+					return combined, err
+				}
+			}
+			return combined, nil
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package mockexec
+
+import (
+	"errors"
+	"testing"
+)
+
+var errPullFailed = errors.New("pull failed")
+
+func Test_TokenizeShellCommand_RespectsQuoting(t *testing.T) {
+	tokens, err := TokenizeShellCommand(`git commit -m "fix: handle spaces" && make build`)
+	if err != nil {
+		t.Fatalf("TokenizeShellCommand() failed: %v", err)
+	}
+	want := []string{"git", "commit", "-m", "fix: handle spaces", "&&", "make", "build"}
+	if len(tokens) != len(want) {
+		t.Fatalf("tokens = %v, want %v", tokens, want)
+	}
+	for i := range want {
+		if tokens[i] != want[i] {
+			t.Errorf("tokens[%d] = %q, want %q", i, tokens[i], want[i])
+		}
+	}
+}
+
+func Test_ShDispatcher_DispatchesInnerCommands(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "pull").Return("pulled\n", nil)
+	mc.ExpectCommand("make", "build").Return("built\n", nil)
+
+	dispatcher := NewShDispatcher(mc.Commander)
+
+	out, err := dispatcher.Commander("sh", "-c", "git pull && make build").Output()
+	if err != nil || string(out) != "pulled\nbuilt\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "pulled\nbuilt\n")
+	}
+}
+
+func Test_ShDispatcher_PassesThroughNonShC(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "status").Return("clean\n", nil)
+
+	dispatcher := NewShDispatcher(mc.Commander)
+
+	out, err := dispatcher.Commander("git", "status").Output()
+	if err != nil || string(out) != "clean\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "clean\n")
+	}
+}
+
+func Test_ShDispatcher_StopsOnError(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "pull").Return("", errPullFailed)
+	mc.ExpectCommand("make", "build").Return("built\n", nil)
+
+	dispatcher := NewShDispatcher(mc.Commander)
+
+	_, err := dispatcher.Commander("sh", "-c", "git pull && make build").Output()
+	if err != errPullFailed {
+		t.Fatalf("Output() error = %v, want %v", err, errPullFailed)
+	}
+	if exp := mc.expectations[1]; exp.Attempts() != 0 {
+		t.Errorf("make build was attempted %d times, want 0 (short-circuited by &&)", exp.Attempts())
+	}
+}
@@ -0,0 +1,23 @@
+package mockexec
+
+// ShellCommand returns a real, exec-backed IShellCommand that runs script
+// through the platform's shell: sh -c on Unix, cmd /C on Windows. Use it
+// (or NewExecShellCommander directly) when the command to run is itself a
+// shell script/pipeline rather than a single binary invocation.
+func ShellCommand(script string) IShellCommand {
+	name, arg := shellInvocation(script)
+	return NewExecShellCommander(name, arg...)
+}
+
+// ExpectShellScript is ExpectCommand's counterpart for ShellCommand: it
+// registers an expectation against whatever name/args ShellCommand(script)
+// would actually invoke on the current platform, so a test written once
+// matches on both Unix and Windows.
+func (mc *MockCommander) ExpectShellScript(script string) *Expectation {
+	name, arg := shellInvocation(script)
+	args := make([]interface{}, len(arg))
+	for i, a := range arg {
+		args[i] = a
+	}
+	return mc.ExpectCommand(name, args...)
+}
@@ -0,0 +1,27 @@
+package mockexec
+
+import "testing"
+
+func Test_ShellCommand_RunsThroughPlatformShell(t *testing.T) {
+	cmd := ShellCommand("echo hello")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+	if got := string(out); got != "hello\n" {
+		t.Errorf("Output() = %q, want %q", got, "hello\n")
+	}
+}
+
+func Test_MockCommander_ExpectShellScript(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectShellScript("echo hello").Return("hello\n", nil)
+
+	name, arg := shellInvocation("echo hello")
+	out, err := mc.Commander(name, arg...).Output()
+	if err != nil || string(out) != "hello\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "hello\n")
+	}
+
+	mc.Verify(t)
+}
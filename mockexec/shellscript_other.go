@@ -0,0 +1,15 @@
+//go:build !windows
+
+package mockexec
+
+// wrapForShellScripts is a no-op on non-Windows platforms, where the kernel
+// itself honors a script's shebang line.
+func wrapForShellScripts(name string, arg []string) (string, []string) {
+	return name, arg
+}
+
+// shellInvocation returns the name/args combination that runs script
+// through the platform's shell: sh -c on every non-Windows platform.
+func shellInvocation(script string) (string, []string) {
+	return "sh", []string{"-c", script}
+}
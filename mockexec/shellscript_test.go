@@ -0,0 +1,13 @@
+package mockexec
+
+import "testing"
+
+// wrapForShellScripts only rewrites .bat/.cmd invocations (Windows-only,
+// see shellscript_windows.go); any other command passes through unchanged
+// on every platform.
+func Test_wrapForShellScripts_LeavesOtherCommandsUnchanged(t *testing.T) {
+	name, arg := wrapForShellScripts("deploy.sh", []string{"--prod"})
+	if name != "deploy.sh" || len(arg) != 1 || arg[0] != "--prod" {
+		t.Errorf("wrapForShellScripts() = (%q, %v), want unchanged", name, arg)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package mockexec
+
+import "strings"
+
+// wrapForShellScripts routes .bat/.cmd scripts through cmd.exe /C, since
+// unlike Unix, Windows doesn't honor a shebang line - exec.Command would
+// otherwise fail trying to execute the script file directly.
+func wrapForShellScripts(name string, arg []string) (string, []string) {
+	lower := strings.ToLower(name)
+	if strings.HasSuffix(lower, ".bat") || strings.HasSuffix(lower, ".cmd") {
+		return "cmd.exe", append([]string{"/C", name}, arg...)
+	}
+	return name, arg
+}
+
+// shellInvocation returns the name/args combination that runs script
+// through the platform's shell: cmd /C on Windows.
+func shellInvocation(script string) (string, []string) {
+	return "cmd", []string{"/C", script}
+}
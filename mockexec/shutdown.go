@@ -0,0 +1,51 @@
+package mockexec
+
+import (
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// GracefulShutdownScenario configures NewGracefulShutdownCommand: a
+// supervisor's SIGTERM-then-SIGKILL escalation is hard to test against a
+// real process because timing is nondeterministic, so this scripts it
+// instead.
+type GracefulShutdownScenario struct {
+	// FinalOutput is what Output returns once the process has exited
+	// gracefully after SIGTERM.
+	FinalOutput string
+	// Delay is how long the process takes to exit once SIGTERM is
+	// delivered, before FinalOutput is produced and Wait unblocks.
+	Delay time.Duration
+}
+
+// NewGracefulShutdownCommand returns a MockShellCommand whose
+// Output/Run/Wait block until a signal is delivered:
+// Signal(syscall.SIGTERM) schedules scenario.FinalOutput and unblocks them
+// after scenario.Delay, modeling a process that shuts down cleanly, while
+// any other signal (including Kill, which is Signal(os.Kill)) unblocks
+// them immediately with no output, modeling a process that dies outright.
+// CombinedOutput does not block: it returns at once with whatever Stdout
+// holds at that moment.
+func NewGracefulShutdownCommand(scenario GracefulShutdownScenario) *MockShellCommand {
+	block := make(chan struct{})
+	var closeOnce sync.Once
+
+	sc := &MockShellCommand{BlockUntil: block}
+	sc.SignalFunc = func(sig os.Signal) error {
+		sc.lastSignal = sig
+		sc.killed = true
+		if sig == syscall.SIGTERM {
+			go func() {
+				time.Sleep(scenario.Delay)
+				sc.Stdout = []byte(scenario.FinalOutput)
+				closeOnce.Do(func() { close(block) })
+			}()
+			return nil
+		}
+		closeOnce.Do(func() { close(block) })
+		return nil
+	}
+	return sc
+}
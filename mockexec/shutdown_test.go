@@ -0,0 +1,67 @@
+package mockexec
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func Test_GracefulShutdownCommand_SIGTERM_ExitsAfterDelayWithFinalOutput(t *testing.T) {
+	cmd := NewGracefulShutdownCommand(GracefulShutdownScenario{
+		FinalOutput: "shutting down cleanly\n",
+		Delay:       20 * time.Millisecond,
+	})
+
+	done := make(chan struct{})
+	var out []byte
+	var err error
+	go func() {
+		out, err = cmd.Output()
+		close(done)
+	}()
+
+	start := time.Now()
+	if sigErr := cmd.Signal(syscall.SIGTERM); sigErr != nil {
+		t.Fatalf("Signal(SIGTERM) failed: %v", sigErr)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Output() did not unblock after SIGTERM")
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Output() unblocked after %v, want at least the scripted delay", elapsed)
+	}
+	if err != nil || string(out) != "shutting down cleanly\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "shutting down cleanly\n")
+	}
+}
+
+func Test_GracefulShutdownCommand_SIGKILL_ExitsImmediatelyWithNoOutput(t *testing.T) {
+	cmd := NewGracefulShutdownCommand(GracefulShutdownScenario{
+		FinalOutput: "shutting down cleanly\n",
+		Delay:       time.Hour,
+	})
+
+	done := make(chan struct{})
+	var out []byte
+	var err error
+	go func() {
+		out, err = cmd.Output()
+		close(done)
+	}()
+
+	if killErr := cmd.Kill(); killErr != nil {
+		t.Fatalf("Kill() failed: %v", killErr)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Output() did not unblock after Kill")
+	}
+	if err != nil || string(out) != "" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "")
+	}
+}
@@ -0,0 +1,21 @@
+package mockexec
+
+import "testing"
+
+func Test_NewSpyCommander_RunsRealCommandAndRecords(t *testing.T) {
+	spy := NewSpyCommander()
+
+	out, err := spy.Commander("echo", "hello").Output()
+	if err != nil || string(out) != "hello\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "hello\n")
+	}
+
+	calls := spy.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("Calls() = %v, want 1 record", calls)
+	}
+	got := calls[0]
+	if got.Name != "echo" || got.Output != "hello\n" || got.ExitCode != 0 {
+		t.Errorf("CallRecord = %+v, want Name=echo Output=%q ExitCode=0", got, "hello\n")
+	}
+}
@@ -0,0 +1,105 @@
+package mockexec
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Transition describes one scripted response registered via
+// StatefulCommander.On. Use Return to configure its output, and To to
+// configure the state it transitions to once matched.
+type Transition struct {
+	fromState string
+	name      string
+	args      []ArgMatcher
+
+	toState string
+	resp    mockResponse
+}
+
+// Return sets the output/error this transition produces when matched.
+func (tr *Transition) Return(output string, err error) *Transition {
+	tr.resp = mockResponse{output: output, err: err}
+	return tr
+}
+
+// To sets the state the commander moves to once this transition is matched.
+// Without a call to To, matching the transition leaves the state unchanged.
+func (tr *Transition) To(state string) *Transition {
+	tr.toState = state
+	return tr
+}
+
+func (tr *Transition) matches(state, name string, args []string) bool {
+	if tr.fromState != "" && tr.fromState != state {
+		return false
+	}
+	if tr.name != name || len(tr.args) != len(args) {
+		return false
+	}
+	for i, m := range tr.args {
+		if !m.Match(args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// StatefulCommander is a commander whose responses depend on a current
+// state as well as the invocation, e.g. so "git status" can answer
+// differently before and after a "git commit". Register transitions with
+// On, in priority order: the first transition matching both the current
+// state and the invocation wins.
+type StatefulCommander struct {
+	mu          sync.Mutex
+	state       string
+	transitions []*Transition
+}
+
+// NewStatefulCommander creates a StatefulCommander starting in initialState.
+func NewStatefulCommander(initialState string) *StatefulCommander {
+	return &StatefulCommander{state: initialState}
+}
+
+// On registers a transition that applies when fromState matches the current
+// state (or always, if fromState is ""), and name/args match the invocation
+// the same way MockCommander.ExpectCommand does.
+func (sc *StatefulCommander) On(fromState, name string, args ...interface{}) *Transition {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	matchers := make([]ArgMatcher, len(args))
+	for i, a := range args {
+		matchers[i] = toArgMatcher(a)
+	}
+	tr := &Transition{fromState: fromState, name: name, args: matchers}
+	sc.transitions = append(sc.transitions, tr)
+	return tr
+}
+
+// State returns the commander's current state, so a test can assert on it
+// directly in addition to asserting on command output.
+func (sc *StatefulCommander) State() string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.state
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (sc *StatefulCommander) Commander(name string, args ...string) IShellCommand {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	for _, tr := range sc.transitions {
+		if !tr.matches(sc.state, name, args) {
+			continue
+		}
+		if tr.toState != "" {
+			sc.state = tr.toState
+		}
+		resp := tr.resp
+		return &MockShellCommand{
+			OutputterFunc: func() ([]byte, error) { return []byte(resp.output), resp.err },
+		}
+	}
+	panic(fmt.Sprintf("mockexec: no transition matches state %q and command %s", sc.state, QuoteCommandLine(name, args)))
+}
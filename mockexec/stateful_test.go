@@ -0,0 +1,37 @@
+package mockexec
+
+import "testing"
+
+func Test_StatefulCommander_GitStatusDirtyUntilCommit(t *testing.T) {
+	sc := NewStatefulCommander("dirty")
+	sc.On("dirty", "git", "status").Return("dirty\n", nil)
+	sc.On("", "git", "commit").Return("", nil).To("clean")
+	sc.On("clean", "git", "status").Return("clean\n", nil)
+
+	out, err := sc.Commander("git", "status").Output()
+	if err != nil || string(out) != "dirty\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "dirty\n")
+	}
+
+	if _, err := sc.Commander("git", "commit").Output(); err != nil {
+		t.Fatalf("git commit Output() failed: %v", err)
+	}
+	if got := sc.State(); got != "clean" {
+		t.Fatalf("State() = %q, want %q", got, "clean")
+	}
+
+	out, err = sc.Commander("git", "status").Output()
+	if err != nil || string(out) != "clean\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "clean\n")
+	}
+}
+
+func Test_StatefulCommander_NoMatchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unmatched state/command combination")
+		}
+	}()
+	sc := NewStatefulCommander("dirty")
+	sc.Commander("git", "status")
+}
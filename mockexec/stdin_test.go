@@ -0,0 +1,135 @@
+package mockexec
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_MockShellCommand_SetStdin_CapturesContent(t *testing.T) {
+	cmd := &MockShellCommand{}
+	cmd.SetStdin(strings.NewReader(`{"ok": true}`))
+
+	if got, want := cmd.CapturedStdin(), `{"ok": true}`; got != want {
+		t.Errorf("CapturedStdin() = %q, want %q", got, want)
+	}
+}
+
+func Test_Expectation_WithStdinJSON_PassesOnStructuralMatch(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("kubectl", "apply", "-f", "-").
+		Return("deployed", nil).
+		WithStdinJSON(`{"kind": "Pod", "metadata": {"name": "x"}}`)
+
+	cmd := mc.Commander("kubectl", "apply", "-f", "-")
+	cmd.SetStdin(strings.NewReader(`{"metadata": {"name": "x"}, "kind": "Pod"}`))
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if spy.Failed() {
+		t.Error("Verify() reported a failure for structurally-equal JSON stdin")
+	}
+}
+
+func Test_Expectation_WithStdinJSON_FailsOnMismatch(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("kubectl", "apply", "-f", "-").
+		Return("deployed", nil).
+		WithStdinJSON(`{"kind": "Pod"}`)
+
+	cmd := mc.Commander("kubectl", "apply", "-f", "-")
+	cmd.SetStdin(strings.NewReader(`{"kind": "Deployment"}`))
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("Verify() did not report a failure for mismatched stdin JSON")
+	}
+}
+
+func Test_Expectation_WithStdinMatching_StdinYAMLEq_PassesOnStructuralMatch(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("kubectl", "apply", "-f", "-").
+		Return("deployed", nil).
+		WithStdinMatching(StdinYAMLEq("kind: Pod\nmetadata:\n  name: x\n"))
+
+	cmd := mc.Commander("kubectl", "apply", "-f", "-")
+	cmd.SetStdin(strings.NewReader("metadata:\n  name: x\nkind: Pod\n"))
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if spy.Failed() {
+		t.Error("Verify() reported a failure for structurally-equal YAML stdin")
+	}
+}
+
+func Test_Expectation_WithStdinMatching_StdinYAMLEq_FailsOnMismatch(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("kubectl", "apply", "-f", "-").
+		Return("deployed", nil).
+		WithStdinMatching(StdinYAMLEq("kind: Pod\n"))
+
+	cmd := mc.Commander("kubectl", "apply", "-f", "-")
+	cmd.SetStdin(strings.NewReader("kind: Deployment\n"))
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("Verify() did not report a failure for mismatched stdin YAML")
+	}
+}
+
+func Test_Expectation_WithStdinMatching_StdinContains_PassesWhenSubstringPresent(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("kubectl", "apply", "-f", "-").
+		Return("deployed", nil).
+		WithStdinMatching(StdinContains("kind: Pod"))
+
+	cmd := mc.Commander("kubectl", "apply", "-f", "-")
+	cmd.SetStdin(strings.NewReader("apiVersion: v1\nkind: Pod\n"))
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if spy.Failed() {
+		t.Error("Verify() reported a failure when the substring was present")
+	}
+}
+
+func Test_Expectation_WithStdinMatching_CustomFunc_ReportsOwnDiff(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("kubectl", "apply", "-f", "-").
+		Return("deployed", nil).
+		WithStdinMatching(func(got string) error {
+			if got != "exact\n" {
+				return fmt.Errorf("custom diff: got %q, want %q", got, "exact\n")
+			}
+			return nil
+		})
+
+	cmd := mc.Commander("kubectl", "apply", "-f", "-")
+	cmd.SetStdin(strings.NewReader("not exact\n"))
+	if _, err := cmd.Output(); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("Verify() did not report a failure for the custom matcher's mismatch")
+	}
+}
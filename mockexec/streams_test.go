@@ -0,0 +1,59 @@
+package mockexec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func Test_MockShellCommand_DistinctStdoutStderr(t *testing.T) {
+	newCmd := func() *MockShellCommand {
+		return &MockShellCommand{
+			Stdout: []byte("data\n"),
+			Stderr: []byte("warning: deprecated flag\n"),
+		}
+	}
+
+	out, err := newCmd().Output()
+	if err != nil || string(out) != "data\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "data\n")
+	}
+
+	combined, err := newCmd().CombinedOutput()
+	if err != nil || string(combined) != "data\nwarning: deprecated flag\n" {
+		t.Errorf("CombinedOutput() = (%q, %v), want stdout+stderr concatenated", combined, err)
+	}
+
+	stderrPipe, err := newCmd().StderrPipe()
+	if err != nil {
+		t.Fatalf("StderrPipe() failed: %v", err)
+	}
+	stderrBytes, err := io.ReadAll(stderrPipe)
+	if err != nil || string(stderrBytes) != "warning: deprecated flag\n" {
+		t.Errorf("StderrPipe() content = (%q, %v), want (%q, nil)", stderrBytes, err, "warning: deprecated flag\n")
+	}
+}
+
+func Test_MockShellCommand_Run_WritesStdoutAndStderrSeparately(t *testing.T) {
+	wantErr := errors.New("exit status 1")
+	cmd := &MockShellCommand{
+		Stdout: []byte("data\n"),
+		Stderr: []byte("warning\n"),
+		Err:    wantErr,
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(&stderr)
+
+	if err := cmd.Run(); err != wantErr {
+		t.Errorf("Run() = %v, want %v", err, wantErr)
+	}
+	if stdout.String() != "data\n" {
+		t.Errorf("stdout = %q, want %q", stdout.String(), "data\n")
+	}
+	if stderr.String() != "warning\n" {
+		t.Errorf("stderr = %q, want %q", stderr.String(), "warning\n")
+	}
+}
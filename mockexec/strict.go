@@ -0,0 +1,32 @@
+package mockexec
+
+// TestingT is the subset of *testing.T that StrictCommander needs. It lets
+// tests pass the real *testing.T (or a *testing.B, or a fake in their own
+// tests) without this package importing "testing" for it.
+type TestingT interface {
+	Fatalf(format string, args ...interface{})
+}
+
+// StrictCommander is a MockCommander that fails the test immediately, via
+// TestingT.Fatalf, when an unmocked command is invoked, instead of silently
+// panicking or falling through to a catch-all response.
+type StrictCommander struct {
+	*MockCommander
+	t TestingT
+}
+
+// NewStrictCommander returns an empty StrictCommander that reports
+// unexpected commands to t.
+func NewStrictCommander(t TestingT) *StrictCommander {
+	return &StrictCommander{MockCommander: NewMockCommander(), t: t}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (sc *StrictCommander) Commander(name string, args ...string) IShellCommand {
+	defer func() {
+		if r := recover(); r != nil {
+			sc.t.Fatalf("%v", r)
+		}
+	}()
+	return sc.MockCommander.Commander(name, args...)
+}
@@ -0,0 +1,37 @@
+package mockexec
+
+import "testing"
+
+type fakeTestingT struct {
+	failed  bool
+	message string
+}
+
+func (f *fakeTestingT) Fatalf(format string, args ...interface{}) {
+	f.failed = true
+	f.message = format
+}
+
+func Test_StrictCommander_MatchedExpectation(t *testing.T) {
+	sc := NewStrictCommander(t)
+	sc.ExpectCommand("git", "status").Return("clean", nil)
+
+	out, err := sc.Commander("git", "status").Output()
+	if err != nil || string(out) != "clean" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "clean")
+	}
+}
+
+func Test_StrictCommander_FailsOnUnexpectedCommand(t *testing.T) {
+	fake := &fakeTestingT{}
+	sc := NewStrictCommander(fake)
+
+	func() {
+		defer func() { recover() }()
+		sc.Commander("rm", "-rf", "/")
+	}()
+
+	if !fake.failed {
+		t.Error("expected Fatalf to be called for an unexpected command")
+	}
+}
@@ -0,0 +1,28 @@
+package mockexec
+
+import "testing"
+
+// Swap installs value into *target and registers a t.Cleanup that restores
+// whatever *target held before the call, so callers no longer need their
+// own swap/defer-restore dance. Nested swaps (e.g. a subtest swapping again
+// on top of its parent's swap) work correctly because each call captures
+// the value that was live at the time it ran, and restores exactly that.
+func Swap[T any](t *testing.T, target *T, value T) {
+	old := *target
+	*target = value
+	t.Cleanup(func() { *target = old })
+}
+
+// WithSwap installs value into *target for the duration of fn, then
+// restores whatever *target held before the call, regardless of how fn
+// returns (including a panic). Use this instead of Swap when a scope
+// narrower than the current subtest is needed - e.g. a more specific mock
+// for just one section of a test function - without having to break that
+// section out into its own t.Run. Nested calls (an inner WithSwap inside
+// an outer one's fn) restore correctly, the same as nested Swap calls do.
+func WithSwap[T any](t *testing.T, target *T, value T, fn func()) {
+	old := *target
+	*target = value
+	defer func() { *target = old }()
+	fn()
+}
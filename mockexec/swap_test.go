@@ -0,0 +1,49 @@
+package mockexec
+
+import "testing"
+
+func Test_Swap_RestoresAndNests(t *testing.T) {
+	var commander ShellCommanderFunc = NewExecShellCommander
+
+	Swap(t, &commander, NewMockShellCommanderForOutput("outer", nil, t))
+	if out, _ := commander("anything").Output(); string(out) != "outer" {
+		t.Fatalf("outer swap not installed, got %q", out)
+	}
+
+	t.Run("nested", func(t *testing.T) {
+		Swap(t, &commander, NewMockShellCommanderForOutput("inner", nil, t))
+		if out, _ := commander("anything").Output(); string(out) != "inner" {
+			t.Fatalf("inner swap not installed, got %q", out)
+		}
+	})
+
+	if out, _ := commander("anything").Output(); string(out) != "outer" {
+		t.Errorf("outer swap not restored after subtest, got %q", out)
+	}
+}
+
+func Test_WithSwap_RestoresAfterFnAndNests(t *testing.T) {
+	var commander ShellCommanderFunc = NewExecShellCommander
+
+	Swap(t, &commander, NewMockShellCommanderForOutput("outer", nil, t))
+
+	WithSwap(t, &commander, NewMockShellCommanderForOutput("middle", nil, t), func() {
+		if out, _ := commander("anything").Output(); string(out) != "middle" {
+			t.Fatalf("middle swap not installed, got %q", out)
+		}
+
+		WithSwap(t, &commander, NewMockShellCommanderForOutput("inner", nil, t), func() {
+			if out, _ := commander("anything").Output(); string(out) != "inner" {
+				t.Fatalf("inner swap not installed, got %q", out)
+			}
+		})
+
+		if out, _ := commander("anything").Output(); string(out) != "middle" {
+			t.Errorf("middle swap not restored after inner WithSwap, got %q", out)
+		}
+	})
+
+	if out, _ := commander("anything").Output(); string(out) != "outer" {
+		t.Errorf("outer swap not restored after WithSwap, got %q", out)
+	}
+}
@@ -0,0 +1,16 @@
+package mockexec
+
+import (
+	"syscall"
+	"testing"
+)
+
+func Test_MockShellCommand_SysProcAttrCaptured(t *testing.T) {
+	sc := &MockShellCommand{}
+	sc.SetSysProcAttr(&syscall.SysProcAttr{Setpgid: true})
+
+	got := sc.SysProcAttr()
+	if got == nil || !got.Setpgid {
+		t.Errorf("SysProcAttr() = %+v, want Setpgid: true", got)
+	}
+}
@@ -0,0 +1,80 @@
+package mockexec
+
+// Reporter is the subset of *testing.T that TeeCommander needs: unlike
+// TestingT's Fatalf, Errorf reports a failure without aborting the test,
+// so a whole run of commands can be tee'd and every drift reported.
+type Reporter interface {
+	Errorf(format string, args ...interface{})
+}
+
+// TeeCommander runs the real, exec-backed command while comparing its
+// output and exit code against the matching entry in fixtures, reporting
+// any drift to t via Errorf. This keeps recorded fixtures honest against
+// the actual tool versions shipped in CI, instead of letting a mocked test
+// suite silently drift from reality as a dependency's output format
+// changes.
+type TeeCommander struct {
+	fixtures *FixtureCommander
+	t        Reporter
+}
+
+// NewTeeCommander returns a TeeCommander comparing real invocations
+// against fixtures, reporting any mismatch to t.
+func NewTeeCommander(fixtures *FixtureCommander, t Reporter) *TeeCommander {
+	return &TeeCommander{fixtures: fixtures, t: t}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander var.
+func (tc *TeeCommander) Commander(name string, arg ...string) IShellCommand {
+	return &teeShellCommand{
+		IShellCommand: NewExecShellCommander(name, arg...),
+		want:          tc.fixtures.Commander(name, arg...),
+		t:             tc.t,
+		name:          name,
+		args:          arg,
+	}
+}
+
+// teeShellCommand wraps the real command, comparing each output-producing
+// call against what the fixture commander would have returned.
+type teeShellCommand struct {
+	IShellCommand
+	want IShellCommand
+	t    Reporter
+	name string
+	args []string
+}
+
+func (tc *teeShellCommand) Output() ([]byte, error) {
+	got, err := tc.IShellCommand.Output()
+	want, _ := tc.want.Output()
+	tc.compare(got, want)
+	return got, err
+}
+
+func (tc *teeShellCommand) CombinedOutput() ([]byte, error) {
+	got, err := tc.IShellCommand.CombinedOutput()
+	want, _ := tc.want.CombinedOutput()
+	tc.compare(got, want)
+	return got, err
+}
+
+func (tc *teeShellCommand) Run() error {
+	err := tc.IShellCommand.Run()
+	tc.compare(nil, nil)
+	return err
+}
+
+// compare reports drift between the real command's output/exit code and
+// the fixture's, unless got/want are both nil (Run, which doesn't capture
+// output, only checks the exit code).
+func (tc *teeShellCommand) compare(got, want []byte) {
+	if got != nil || want != nil {
+		if string(got) != string(want) {
+			tc.t.Errorf("mockexec: tee drift for %v %v: real output %q, fixture output %q", tc.name, tc.args, got, want)
+		}
+	}
+	if gotExit, wantExit := tc.IShellCommand.ExitCode(), tc.want.ExitCode(); gotExit != wantExit {
+		tc.t.Errorf("mockexec: tee drift for %v %v: real exit code %d, fixture exit code %d", tc.name, tc.args, gotExit, wantExit)
+	}
+}
@@ -0,0 +1,53 @@
+package mockexec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type fakeReporter struct {
+	errors []string
+}
+
+func (f *fakeReporter) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, format)
+}
+
+func loadTeeFixtures(t *testing.T, json string) *FixtureCommander {
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	if err := os.WriteFile(path, []byte(json), 0o644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+	fc, err := LoadFixtures(path)
+	if err != nil {
+		t.Fatalf("LoadFixtures() failed: %v", err)
+	}
+	return fc
+}
+
+func Test_TeeCommander_PassesWhenRealMatchesFixture(t *testing.T) {
+	fixtures := loadTeeFixtures(t, `[{"command": "echo", "args": ["hello"], "stdout": "hello\n", "exitCode": 0}]`)
+	reporter := &fakeReporter{}
+	tc := NewTeeCommander(fixtures, reporter)
+
+	out, err := tc.Commander("echo", "hello").Output()
+	if err != nil || string(out) != "hello\n" {
+		t.Fatalf("Output() = (%q, %v), want (%q, nil)", out, err, "hello\n")
+	}
+	if len(reporter.errors) != 0 {
+		t.Errorf("expected no drift reported, got %v", reporter.errors)
+	}
+}
+
+func Test_TeeCommander_ReportsDriftOnMismatch(t *testing.T) {
+	fixtures := loadTeeFixtures(t, `[{"command": "echo", "args": ["hello"], "stdout": "stale output\n", "exitCode": 0}]`)
+	reporter := &fakeReporter{}
+	tc := NewTeeCommander(fixtures, reporter)
+
+	tc.Commander("echo", "hello").Output()
+
+	if len(reporter.errors) == 0 {
+		t.Error("expected drift between real output and stale fixture to be reported")
+	}
+}
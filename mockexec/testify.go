@@ -0,0 +1,126 @@
+package mockexec
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// TestifyShellCommand is an IShellCommand built on testify's mock.Mock, for
+// teams that already use On(...).Return(...), AssertCalled, and
+// AssertNumberOfCalls elsewhere and would rather not hand-roll
+// OutputterFunc closures for exec mocking too.
+//
+// Typical usage:
+//
+//	m := new(mockexec.TestifyShellCommand)
+//	m.On("Output").Return([]byte("main\n"), nil)
+//	commander := func(name string, arg ...string) mockexec.IShellCommand { return m }
+type TestifyShellCommand struct {
+	mock.Mock
+}
+
+func (m *TestifyShellCommand) SetDir(dir string) {
+	m.Called(dir)
+}
+
+func (m *TestifyShellCommand) Output() ([]byte, error) {
+	args := m.Called()
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *TestifyShellCommand) CombinedOutput() ([]byte, error) {
+	args := m.Called()
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *TestifyShellCommand) Run() error {
+	return m.Called().Error(0)
+}
+
+func (m *TestifyShellCommand) Start() error {
+	return m.Called().Error(0)
+}
+
+func (m *TestifyShellCommand) Wait() error {
+	return m.Called().Error(0)
+}
+
+func (m *TestifyShellCommand) StdoutPipe() (io.ReadCloser, error) {
+	args := m.Called()
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *TestifyShellCommand) StderrPipe() (io.ReadCloser, error) {
+	args := m.Called()
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *TestifyShellCommand) StdinPipe() (io.WriteCloser, error) {
+	args := m.Called()
+	return args.Get(0).(io.WriteCloser), args.Error(1)
+}
+
+func (m *TestifyShellCommand) SetEnv(env []string) {
+	m.Called(env)
+}
+
+func (m *TestifyShellCommand) Environ() []string {
+	return m.Called().Get(0).([]string)
+}
+
+func (m *TestifyShellCommand) SetStdin(r io.Reader) {
+	m.Called(r)
+}
+
+func (m *TestifyShellCommand) SetStdout(w io.Writer) {
+	m.Called(w)
+}
+
+func (m *TestifyShellCommand) SetStderr(w io.Writer) {
+	m.Called(w)
+}
+
+func (m *TestifyShellCommand) ExitCode() int {
+	return m.Called().Int(0)
+}
+
+func (m *TestifyShellCommand) Kill() error {
+	return m.Called().Error(0)
+}
+
+func (m *TestifyShellCommand) Signal(sig os.Signal) error {
+	return m.Called(sig).Error(0)
+}
+
+func (m *TestifyShellCommand) Pid() int {
+	return m.Called().Int(0)
+}
+
+func (m *TestifyShellCommand) ProcessState() ProcessState {
+	ps, _ := m.Called().Get(0).(ProcessState)
+	return ps
+}
+
+func (m *TestifyShellCommand) SetCancel(cancel func() error) {
+	m.Called(cancel)
+}
+
+func (m *TestifyShellCommand) SetWaitDelay(d time.Duration) {
+	m.Called(d)
+}
+
+func (m *TestifyShellCommand) SetSysProcAttr(attr *syscall.SysProcAttr) {
+	m.Called(attr)
+}
+
+func (m *TestifyShellCommand) SetExtraFiles(files []*os.File) {
+	m.Called(files)
+}
+
+func (m *TestifyShellCommand) LookupErr() error {
+	return m.Called().Error(0)
+}
@@ -0,0 +1,14 @@
+package mockexec
+
+import "testing"
+
+func Test_TestifyShellCommand_OnReturn(t *testing.T) {
+	m := new(TestifyShellCommand)
+	m.On("Output").Return([]byte("main\n"), error(nil))
+
+	out, err := m.Output()
+	if err != nil || string(out) != "main\n" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "main\n")
+	}
+	m.AssertNumberOfCalls(t, "Output", 1)
+}
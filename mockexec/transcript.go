@@ -0,0 +1,120 @@
+package mockexec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Transcript is one recorded real invocation: what was run and what it
+// produced. TranscriptRecorder.Save writes these out; LoadTranscripts reads
+// them back for replay in CI environments where the real binaries aren't
+// installed.
+type Transcript struct {
+	Name     string   `json:"name"`
+	Args     []string `json:"args"`
+	Stdout   string   `json:"stdout"`
+	Stderr   string   `json:"stderr"`
+	ExitCode int      `json:"exitCode"`
+}
+
+// TranscriptRecorder wraps the real exec-backed commander, runs actual
+// commands, and keeps a transcript of each one for later replay.
+type TranscriptRecorder struct {
+	mu          sync.Mutex
+	transcripts []Transcript
+}
+
+// NewTranscriptRecorder returns a recorder with no transcripts yet.
+func NewTranscriptRecorder() *TranscriptRecorder {
+	return &TranscriptRecorder{}
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander
+// var while recording.
+func (tr *TranscriptRecorder) Commander(name string, arg ...string) IShellCommand {
+	real := NewExecShellCommander(name, arg...)
+	var stdout, stderr bytes.Buffer
+	real.SetStdout(&stdout)
+	real.SetStderr(&stderr)
+
+	err := real.Run()
+	t := Transcript{
+		Name:     name,
+		Args:     arg,
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		ExitCode: real.ExitCode(),
+	}
+
+	tr.mu.Lock()
+	tr.transcripts = append(tr.transcripts, t)
+	tr.mu.Unlock()
+
+	return &MockShellCommand{
+		OutputterFunc: func() ([]byte, error) { return []byte(t.Stdout), err },
+		ExitCodeValue: t.ExitCode,
+	}
+}
+
+// Save writes every recorded transcript to path as JSON.
+func (tr *TranscriptRecorder) Save(path string) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	data, err := json.MarshalIndent(tr.transcripts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ReplayCommander serves previously recorded transcripts in the order they
+// were saved, one per matching command name.
+type ReplayCommander struct {
+	mu    sync.Mutex
+	queue map[string][]Transcript
+}
+
+// LoadTranscripts reads transcripts saved by TranscriptRecorder.Save and
+// returns a commander that replays them.
+func LoadTranscripts(path string) (*ReplayCommander, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var transcripts []Transcript
+	if err := json.Unmarshal(data, &transcripts); err != nil {
+		return nil, err
+	}
+	queue := make(map[string][]Transcript)
+	for _, t := range transcripts {
+		queue[t.Name] = append(queue[t.Name], t)
+	}
+	return &ReplayCommander{queue: queue}, nil
+}
+
+// Commander is the ShellCommanderFunc to assign to a package's commander
+// var while replaying. It panics if more calls are made for a given name
+// than were recorded.
+func (rc *ReplayCommander) Commander(name string, arg ...string) IShellCommand {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	queued := rc.queue[name]
+	if len(queued) == 0 {
+		panic(fmt.Sprintf("mockexec: no recorded transcript left for command %q", name))
+	}
+	t := queued[0]
+	rc.queue[name] = queued[1:]
+
+	var err error
+	if t.ExitCode != 0 {
+		err = fmt.Errorf("exit status %d", t.ExitCode)
+	}
+	return &MockShellCommand{
+		OutputterFunc: func() ([]byte, error) { return []byte(t.Stdout), err },
+		ExitCodeValue: t.ExitCode,
+	}
+}
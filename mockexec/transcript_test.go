@@ -0,0 +1,28 @@
+package mockexec
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_TranscriptRecorder_RecordAndReplay(t *testing.T) {
+	tr := NewTranscriptRecorder()
+	tr.Commander("echo", "hello")
+
+	path := filepath.Join(t.TempDir(), "transcript.json")
+	if err := tr.Save(path); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	replay, err := LoadTranscripts(path)
+	if err != nil {
+		t.Fatalf("LoadTranscripts() failed: %v", err)
+	}
+	out, err := replay.Commander("echo", "hello").Output()
+	if err != nil {
+		t.Fatalf("replayed Output() failed: %v", err)
+	}
+	if got := string(out); got != "hello\n" {
+		t.Errorf("replayed Output() = %q, want %q", got, "hello\n")
+	}
+}
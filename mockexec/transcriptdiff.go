@@ -0,0 +1,79 @@
+package mockexec
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// DiffOptions configures DiffTranscripts' comparison.
+type DiffOptions struct {
+	// IgnorePatterns lists regexps whose matches are replaced with
+	// "<ignored>" in every field before comparing, so volatile content
+	// (timestamps, temp paths, PIDs) doesn't register as a regression.
+	IgnorePatterns []*regexp.Regexp
+}
+
+// DiffKind identifies how one InvocationDiff differs between two
+// transcript runs.
+type DiffKind string
+
+const (
+	DiffAdded   DiffKind = "added"
+	DiffRemoved DiffKind = "removed"
+	DiffChanged DiffKind = "changed"
+)
+
+// InvocationDiff describes one positional difference found by
+// DiffTranscripts: A and B hold the original (un-normalized) transcripts,
+// so a report can show the real content even though normalization decided
+// whether they differ.
+type InvocationDiff struct {
+	Index int
+	Kind  DiffKind
+	A     *Transcript
+	B     *Transcript
+}
+
+// DiffTranscripts compares two recorded runs positionally - index i of a
+// against index i of b - reporting an "added"/"removed" diff for indices
+// only one side has, and a "changed" diff for indices where the
+// (normalized) content differs, so a release branch's behavior change
+// shows up as a short, reviewable list instead of two full JSON files.
+func DiffTranscripts(a, b []Transcript, opts DiffOptions) []InvocationDiff {
+	var diffs []InvocationDiff
+
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		switch {
+		case i >= len(a):
+			bb := b[i]
+			diffs = append(diffs, InvocationDiff{Index: i, Kind: DiffAdded, B: &bb})
+		case i >= len(b):
+			aa := a[i]
+			diffs = append(diffs, InvocationDiff{Index: i, Kind: DiffRemoved, A: &aa})
+		default:
+			aa, bb := a[i], b[i]
+			if normalizeTranscript(aa, opts) != normalizeTranscript(bb, opts) {
+				diffs = append(diffs, InvocationDiff{Index: i, Kind: DiffChanged, A: &aa, B: &bb})
+			}
+		}
+	}
+	return diffs
+}
+
+// normalizeTranscript renders t as a comparable string with every
+// IgnorePatterns match replaced by a placeholder.
+func normalizeTranscript(t Transcript, opts DiffOptions) string {
+	s := t.Name
+	for _, a := range t.Args {
+		s += "\x00" + a
+	}
+	s += "\x00" + t.Stdout + "\x00" + t.Stderr + "\x00" + strconv.Itoa(t.ExitCode)
+	for _, pattern := range opts.IgnorePatterns {
+		s = pattern.ReplaceAllString(s, "<ignored>")
+	}
+	return s
+}
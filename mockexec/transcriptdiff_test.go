@@ -0,0 +1,63 @@
+package mockexec
+
+import (
+	"regexp"
+	"testing"
+)
+
+func Test_DiffTranscripts_DetectsAddedRemovedChanged(t *testing.T) {
+	a := []Transcript{
+		{Name: "git", Args: []string{"status"}, Stdout: "clean\n"},
+		{Name: "git", Args: []string{"log"}, Stdout: "deadbeef\n"},
+	}
+	b := []Transcript{
+		{Name: "git", Args: []string{"status"}, Stdout: "dirty\n"},
+		{Name: "git", Args: []string{"log"}, Stdout: "deadbeef\n"},
+		{Name: "git", Args: []string{"push"}, Stdout: "ok\n"},
+	}
+
+	diffs := DiffTranscripts(a, b, DiffOptions{})
+	if len(diffs) != 2 {
+		t.Fatalf("DiffTranscripts() returned %d diffs, want 2: %+v", len(diffs), diffs)
+	}
+	if diffs[0].Kind != DiffChanged || diffs[0].Index != 0 {
+		t.Errorf("diffs[0] = %+v, want Kind=changed Index=0", diffs[0])
+	}
+	if diffs[1].Kind != DiffAdded || diffs[1].Index != 2 {
+		t.Errorf("diffs[1] = %+v, want Kind=added Index=2", diffs[1])
+	}
+}
+
+func Test_DiffTranscripts_IgnorePatterns_SuppressesVolatileDiff(t *testing.T) {
+	a := []Transcript{
+		{Name: "build", Stdout: "built in /tmp/build-abc123 at 2026-01-01T00:00:00Z\n"},
+	}
+	b := []Transcript{
+		{Name: "build", Stdout: "built in /tmp/build-xyz987 at 2026-06-15T12:30:00Z\n"},
+	}
+
+	opts := DiffOptions{IgnorePatterns: []*regexp.Regexp{
+		regexp.MustCompile(`/tmp/build-\w+`),
+		regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z`),
+	}}
+
+	diffs := DiffTranscripts(a, b, opts)
+	if len(diffs) != 0 {
+		t.Errorf("DiffTranscripts() with ignore patterns = %+v, want no diffs", diffs)
+	}
+}
+
+func Test_DiffTranscripts_RemovedWhenBIsShorter(t *testing.T) {
+	a := []Transcript{
+		{Name: "git", Args: []string{"status"}},
+		{Name: "git", Args: []string{"log"}},
+	}
+	b := []Transcript{
+		{Name: "git", Args: []string{"status"}},
+	}
+
+	diffs := DiffTranscripts(a, b, DiffOptions{})
+	if len(diffs) != 1 || diffs[0].Kind != DiffRemoved || diffs[0].Index != 1 {
+		t.Errorf("DiffTranscripts() = %+v, want one removed diff at index 1", diffs)
+	}
+}
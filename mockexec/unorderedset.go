@@ -0,0 +1,16 @@
+package mockexec
+
+// UnorderedSet defaults each of the given expectations to Times(1), unless
+// its cardinality was already configured explicitly, so a batch of
+// concurrently launched commands can be asserted as "exactly these calls
+// happened, regardless of order" - register them normally, pass them
+// through UnorderedSet, and check the result with MockCommander.Verify.
+// It's the complement to InOrder, which pins relative order instead of
+// exact counts.
+func UnorderedSet(exps ...*Expectation) {
+	for _, e := range exps {
+		if e.wantCalls == (callCardinality{}) {
+			e.Times(1)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package mockexec
+
+import "testing"
+
+func Test_UnorderedSet_PassesRegardlessOfCallOrder(t *testing.T) {
+	mc := NewMockCommander()
+	build := mc.ExpectCommand("docker", "build").Return("", nil)
+	push := mc.ExpectCommand("docker", "push").Return("", nil)
+	tag := mc.ExpectCommand("docker", "tag").Return("", nil)
+	UnorderedSet(build, push, tag)
+
+	mc.Commander("docker", "tag")
+	mc.Commander("docker", "build")
+	mc.Commander("docker", "push")
+
+	mc.Verify(t)
+}
+
+func Test_UnorderedSet_FailsWhenOneNeverCalled(t *testing.T) {
+	mc := NewMockCommander()
+	build := mc.ExpectCommand("docker", "build").Return("", nil)
+	push := mc.ExpectCommand("docker", "push").Return("", nil)
+	UnorderedSet(build, push)
+
+	mc.Commander("docker", "build")
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("expected Verify to fail when a set member was never called")
+	}
+}
+
+func Test_UnorderedSet_FailsWhenCalledTwice(t *testing.T) {
+	mc := NewMockCommander()
+	build := mc.ExpectCommand("docker", "build").Return("", nil)
+	UnorderedSet(build)
+
+	mc.Commander("docker", "build")
+	mc.Commander("docker", "build")
+
+	spy := &testing.T{}
+	mc.Verify(spy)
+	if !spy.Failed() {
+		t.Error("expected Verify to fail when a Times(1) set member was called twice")
+	}
+}
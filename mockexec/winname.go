@@ -0,0 +1,17 @@
+package mockexec
+
+import (
+	"path"
+	"strings"
+)
+
+// normalizeCommandName lowercases name, strips a trailing ".exe", and
+// reduces it to its base name after normalizing backslashes to forward
+// slashes, so "git", "git.exe", and "C:\Program Files\Git\bin\git.exe" all
+// compare equal. Used by Expectation matching when
+// MockCommander.NormalizeWindowsNames is set.
+func normalizeCommandName(name string) string {
+	name = strings.ReplaceAll(name, `\`, "/")
+	base := path.Base(name)
+	return strings.TrimSuffix(strings.ToLower(base), ".exe")
+}
@@ -0,0 +1,41 @@
+package mockexec
+
+import "testing"
+
+func Test_normalizeCommandName(t *testing.T) {
+	cases := map[string]string{
+		"git":                              "git",
+		"git.exe":                          "git",
+		"Git.EXE":                          "git",
+		`C:\Program Files\Git\bin\git.exe`: "git",
+		"/usr/bin/git":                     "git",
+	}
+	for in, want := range cases {
+		if got := normalizeCommandName(in); got != want {
+			t.Errorf("normalizeCommandName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func Test_MockCommander_NormalizeWindowsNames(t *testing.T) {
+	mc := NewMockCommander()
+	mc.NormalizeWindowsNames = true
+	mc.ExpectCommand("git", "status").Return("clean", nil)
+
+	out, err := mc.Commander("git.exe", "status").Output()
+	if err != nil || string(out) != "clean" {
+		t.Errorf("Output() = (%q, %v), want (%q, nil)", out, err, "clean")
+	}
+}
+
+func Test_MockCommander_NormalizeWindowsNamesOffByDefault(t *testing.T) {
+	mc := NewMockCommander()
+	mc.ExpectCommand("git", "status").Return("clean", nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Commander to panic on git.exe when normalization is off")
+		}
+	}()
+	mc.Commander("git.exe", "status")
+}